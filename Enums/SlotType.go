@@ -0,0 +1,11 @@
+package Enums
+
+type SlotType string
+
+const (
+	STANDARD   SlotType = "STANDARD"
+	COMPACT    SlotType = "COMPACT"
+	LARGE      SlotType = "LARGE"
+	ELECTRIC   SlotType = "ELECTRIC"
+	ACCESSIBLE SlotType = "ACCESSIBLE"
+)
@@ -0,0 +1,11 @@
+package Enums
+
+type VehicleType string
+
+const (
+	CAR        VehicleType = "CAR"
+	MOTORCYCLE VehicleType = "MOTORCYCLE"
+	SUV        VehicleType = "SUV"
+	VAN        VehicleType = "VAN"
+	TRUCK      VehicleType = "TRUCK"
+)
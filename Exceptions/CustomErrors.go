@@ -1,14 +1,50 @@
 package Exceptions
 
-import "errors"
-
 var (
-	ErrCarAlreadyParked                = errors.New("car already parked")
-	ErrCarNeedsRegistrationNumber      = errors.New("car needs registration number")
-	ErrInvalidTicket                   = errors.New("invalid ticket")
-	ErrParkingLotAlreadyAssigned       = errors.New("parking lot already assigned")
-	ErrParkingLotIsFull                = errors.New("parking lot is full")
-	ErrSlotIsOccupied                  = errors.New("slot is occupied")
-	ErrCarNotFound                     = errors.New("car not found")
-	ErrCannotCreateParkingLotException = errors.New("Parking lot size must be positive.")
+	ErrCarAlreadyParked                = newParkingError(CodeCarAlreadyParked, "car already parked")
+	ErrCarAlreadyAssigned              = newParkingError(CodeCarAlreadyAssigned, "car already assigned to this parking lot")
+	ErrCarNeedsRegistrationNumber      = newParkingError(CodeCarNeedsRegistration, "car needs registration number")
+	ErrInvalidTicket                   = newParkingError(CodeInvalidTicket, "invalid ticket")
+	ErrParkingLotAlreadyAssigned       = newParkingError(CodeParkingLotAssigned, "parking lot already assigned")
+	ErrParkingLotNotOwned              = newParkingError(CodeParkingLotNotOwned, "this parking lot is not owned by the owner")
+	ErrParkingLotIsFull                = newParkingError(CodeParkingLotFull, "parking lot is full")
+	ErrAllParkingLotsFull              = newParkingError(CodeAllParkingLotsFull, "all parking lots are full")
+	ErrNoParkingLotAssigned            = newParkingError(CodeNoParkingLotAssigned, "no parking lot assigned")
+	ErrSlotIsOccupied                  = newParkingError(CodeSlotOccupied, "slot is already occupied")
+	ErrSlotIsEmpty                     = newParkingError(CodeSlotEmpty, "car not found in the slot")
+	ErrCarNotFound                     = newParkingError(CodeCarNotFound, "car not found")
+	ErrCannotCreateParkingLotException = newParkingError(CodeInvalidParkingLotSize, "parking lot size must be positive")
+	ErrParkingLotNeedsOwner            = newParkingError(CodeParkingLotNeedsOwner, "parking lot needs an owner")
+	ErrInconsistentState               = newParkingError(CodeInconsistentState, "attendent bookkeeping does not match parking lot state")
+	ErrAttendentOffShift               = newParkingError(CodeAttendentOffShift, "attendent is not on shift")
+	ErrInvalidShift                    = newParkingError(CodeInvalidShift, "shift must end after it starts")
+	ErrShiftOverlaps                   = newParkingError(CodeShiftOverlaps, "shift overlaps an existing shift")
+	ErrNoScheduledShift                = newParkingError(CodeNoScheduledShift, "no shift scheduled for now")
+	ErrAttendentOnAnotherRoster        = newParkingError(CodeAttendentOnAnotherRoster, "attendent is already scheduled on another roster")
+	ErrAlreadyClockedIn                = newParkingError(CodeAlreadyClockedIn, "attendent already clocked in")
+	ErrNotClockedIn                    = newParkingError(CodeNotClockedIn, "attendent is not clocked in")
+	ErrPendingHandover                 = newParkingError(CodePendingHandover, "attendent must hand over parked cars before clocking out")
+	ErrAttendentAlreadyHired           = newParkingError(CodeAttendentAlreadyHired, "attendent already hired")
+	ErrAttendentEmployedElsewhere      = newParkingError(CodeAttendentEmployedElsewhere, "attendent works for another owner")
+	ErrNoAttendentAvailable            = newParkingError(CodeNoAttendentAvailable, "no attendent available")
+	ErrAttendentNotEmployed            = newParkingError(CodeAttendentNotEmployed, "attendent is not employed by this owner")
+	ErrParkingLotNotAssigned           = newParkingError(CodeParkingLotNotAssigned, "parking lot is not assigned to this attendent")
+	ErrInvalidContract                 = newParkingError(CodeInvalidContract, "contract must end after it starts")
+	ErrContractNotActive               = newParkingError(CodeContractNotActive, "no active contract covers this parking lot")
+	ErrOverlappingContract             = newParkingError(CodeOverlappingContract, "attendent is already contracted for this parking lot in that period")
+	ErrInvalidTransfer                 = newParkingError(CodeInvalidTransfer, "parking lot must be transferred to a different owner")
+	ErrSupervisorCycle                 = newParkingError(CodeSupervisorCycle, "supervisor cannot supervise itself")
+	ErrPermissionDenied                = newParkingError(CodePermissionDenied, "permission denied")
+	ErrSlotNotFound                    = newParkingError(CodeSlotNotFound, "slot not found")
+	ErrSlotOutOfService                = newParkingError(CodeSlotOutOfService, "slot is out of service")
+	ErrInvalidThreshold                = newParkingError(CodeInvalidThreshold, "invalid occupancy threshold")
+	ErrEventBusClosed                  = newParkingError(CodeEventBusClosed, "event bus is closed")
+	ErrWebhookDeliveryFailed           = newParkingError(CodeWebhookDeliveryFailed, "webhook delivery failed")
+	ErrEmailDeliveryFailed             = newParkingError(CodeEmailDeliveryFailed, "email delivery failed")
+	ErrInvalidStateLog                 = newParkingError(CodeInvalidStateLog, "state log cannot be replayed")
+	ErrParkingLotInEmergency           = newParkingError(CodeParkingLotInEmergency, "parking lot is closed for an emergency")
+	ErrNoEmergency                     = newParkingError(CodeNoEmergency, "no emergency in progress at this parking lot")
+	ErrInvalidSnapshot                 = newParkingError(CodeInvalidSnapshot, "snapshot cannot be restored")
+	ErrUnsupportedSnapshotVersion      = newParkingError(CodeUnsupportedSnapshotVersion, "snapshot version is not supported")
+	ErrDecisionNotFound                = newParkingError(CodeDecisionNotFound, "no parking decision recorded for ticket")
 )
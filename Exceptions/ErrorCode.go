@@ -0,0 +1,139 @@
+package Exceptions
+
+import "net/http"
+
+type ErrorCode string
+
+const (
+	CodeUnknown                    ErrorCode = "UNKNOWN"
+	CodeCarAlreadyParked           ErrorCode = "CAR_ALREADY_PARKED"
+	CodeCarAlreadyAssigned         ErrorCode = "CAR_ALREADY_ASSIGNED"
+	CodeCarNeedsRegistration       ErrorCode = "CAR_NEEDS_REGISTRATION"
+	CodeCarNotFound                ErrorCode = "CAR_NOT_FOUND"
+	CodeInvalidTicket              ErrorCode = "INVALID_TICKET"
+	CodeInvalidParkingLotSize      ErrorCode = "INVALID_PARKING_LOT_SIZE"
+	CodeParkingLotNeedsOwner       ErrorCode = "PARKING_LOT_NEEDS_OWNER"
+	CodeParkingLotAssigned         ErrorCode = "PARKING_LOT_ALREADY_ASSIGNED"
+	CodeParkingLotNotOwned         ErrorCode = "PARKING_LOT_NOT_OWNED"
+	CodeParkingLotFull             ErrorCode = "PARKING_LOT_FULL"
+	CodeAllParkingLotsFull         ErrorCode = "ALL_PARKING_LOTS_FULL"
+	CodeNoParkingLotAssigned       ErrorCode = "NO_PARKING_LOT_ASSIGNED"
+	CodeSlotOccupied               ErrorCode = "SLOT_OCCUPIED"
+	CodeSlotEmpty                  ErrorCode = "SLOT_EMPTY"
+	CodeDecisionNotFound           ErrorCode = "DECISION_NOT_FOUND"
+	CodeInconsistentState          ErrorCode = "INCONSISTENT_STATE"
+	CodeAttendentOffShift          ErrorCode = "ATTENDENT_OFF_SHIFT"
+	CodeInvalidShift               ErrorCode = "INVALID_SHIFT"
+	CodeShiftOverlaps              ErrorCode = "SHIFT_OVERLAPS"
+	CodeNoScheduledShift           ErrorCode = "NO_SCHEDULED_SHIFT"
+	CodeAttendentOnAnotherRoster   ErrorCode = "ATTENDENT_ON_ANOTHER_ROSTER"
+	CodeAlreadyClockedIn           ErrorCode = "ALREADY_CLOCKED_IN"
+	CodeNotClockedIn               ErrorCode = "NOT_CLOCKED_IN"
+	CodePendingHandover            ErrorCode = "PENDING_HANDOVER"
+	CodeAttendentAlreadyHired      ErrorCode = "ATTENDENT_ALREADY_HIRED"
+	CodeAttendentEmployedElsewhere ErrorCode = "ATTENDENT_EMPLOYED_ELSEWHERE"
+	CodeNoAttendentAvailable       ErrorCode = "NO_ATTENDENT_AVAILABLE"
+	CodeAttendentNotEmployed       ErrorCode = "ATTENDENT_NOT_EMPLOYED"
+	CodeParkingLotNotAssigned      ErrorCode = "PARKING_LOT_NOT_ASSIGNED"
+	CodeInvalidContract            ErrorCode = "INVALID_CONTRACT"
+	CodeContractNotActive          ErrorCode = "CONTRACT_NOT_ACTIVE"
+	CodeOverlappingContract        ErrorCode = "OVERLAPPING_CONTRACT"
+	CodeInvalidTransfer            ErrorCode = "INVALID_TRANSFER"
+	CodeSupervisorCycle            ErrorCode = "SUPERVISOR_CYCLE"
+	CodePermissionDenied           ErrorCode = "PERMISSION_DENIED"
+	CodeSlotNotFound               ErrorCode = "SLOT_NOT_FOUND"
+	CodeSlotOutOfService           ErrorCode = "SLOT_OUT_OF_SERVICE"
+	CodeInvalidThreshold           ErrorCode = "INVALID_THRESHOLD"
+	CodeEventBusClosed             ErrorCode = "EVENT_BUS_CLOSED"
+	CodeWebhookDeliveryFailed      ErrorCode = "WEBHOOK_DELIVERY_FAILED"
+	CodeEmailDeliveryFailed        ErrorCode = "EMAIL_DELIVERY_FAILED"
+	CodeInvalidStateLog            ErrorCode = "INVALID_STATE_LOG"
+	CodeParkingLotInEmergency      ErrorCode = "PARKING_LOT_IN_EMERGENCY"
+	CodeNoEmergency                ErrorCode = "NO_EMERGENCY"
+	CodeInvalidSnapshot            ErrorCode = "INVALID_SNAPSHOT"
+	CodeUnsupportedSnapshotVersion ErrorCode = "UNSUPPORTED_SNAPSHOT_VERSION"
+)
+
+// GRPCCode mirrors the canonical gRPC status codes so callers can map errors
+// without this module depending on the gRPC runtime.
+type GRPCCode int
+
+const (
+	GRPCOk                 GRPCCode = 0
+	GRPCInvalidArgument    GRPCCode = 3
+	GRPCNotFound           GRPCCode = 5
+	GRPCAlreadyExists      GRPCCode = 6
+	GRPCPermissionDenied   GRPCCode = 7
+	GRPCResourceExhausted  GRPCCode = 8
+	GRPCFailedPrecondition GRPCCode = 9
+	GRPCInternal           GRPCCode = 13
+	GRPCUnavailable        GRPCCode = 14
+)
+
+type statusMapping struct {
+	http int
+	grpc GRPCCode
+}
+
+var statusMappings = map[ErrorCode]statusMapping{
+	CodeCarAlreadyParked:           {http.StatusConflict, GRPCAlreadyExists},
+	CodeCarAlreadyAssigned:         {http.StatusConflict, GRPCAlreadyExists},
+	CodeCarNeedsRegistration:       {http.StatusBadRequest, GRPCInvalidArgument},
+	CodeCarNotFound:                {http.StatusNotFound, GRPCNotFound},
+	CodeInvalidTicket:              {http.StatusBadRequest, GRPCInvalidArgument},
+	CodeInvalidParkingLotSize:      {http.StatusBadRequest, GRPCInvalidArgument},
+	CodeParkingLotNeedsOwner:       {http.StatusBadRequest, GRPCInvalidArgument},
+	CodeParkingLotAssigned:         {http.StatusConflict, GRPCAlreadyExists},
+	CodeParkingLotNotOwned:         {http.StatusForbidden, GRPCPermissionDenied},
+	CodeParkingLotFull:             {http.StatusConflict, GRPCResourceExhausted},
+	CodeAllParkingLotsFull:         {http.StatusConflict, GRPCResourceExhausted},
+	CodeNoParkingLotAssigned:       {http.StatusPreconditionFailed, GRPCFailedPrecondition},
+	CodeSlotOccupied:               {http.StatusConflict, GRPCFailedPrecondition},
+	CodeSlotEmpty:                  {http.StatusNotFound, GRPCNotFound},
+	CodeDecisionNotFound:           {http.StatusNotFound, GRPCNotFound},
+	CodeInconsistentState:          {http.StatusInternalServerError, GRPCInternal},
+	CodeAttendentOffShift:          {http.StatusConflict, GRPCFailedPrecondition},
+	CodeInvalidShift:               {http.StatusBadRequest, GRPCInvalidArgument},
+	CodeShiftOverlaps:              {http.StatusConflict, GRPCAlreadyExists},
+	CodeNoScheduledShift:           {http.StatusPreconditionFailed, GRPCFailedPrecondition},
+	CodeAttendentOnAnotherRoster:   {http.StatusConflict, GRPCFailedPrecondition},
+	CodeAlreadyClockedIn:           {http.StatusConflict, GRPCAlreadyExists},
+	CodeNotClockedIn:               {http.StatusPreconditionFailed, GRPCFailedPrecondition},
+	CodePendingHandover:            {http.StatusPreconditionFailed, GRPCFailedPrecondition},
+	CodeAttendentAlreadyHired:      {http.StatusConflict, GRPCAlreadyExists},
+	CodeAttendentEmployedElsewhere: {http.StatusConflict, GRPCFailedPrecondition},
+	CodeNoAttendentAvailable:       {http.StatusServiceUnavailable, GRPCUnavailable},
+	CodeAttendentNotEmployed:       {http.StatusNotFound, GRPCNotFound},
+	CodeParkingLotNotAssigned:      {http.StatusNotFound, GRPCNotFound},
+	CodeInvalidContract:            {http.StatusBadRequest, GRPCInvalidArgument},
+	CodeContractNotActive:          {http.StatusForbidden, GRPCPermissionDenied},
+	CodeOverlappingContract:        {http.StatusConflict, GRPCFailedPrecondition},
+	CodeInvalidTransfer:            {http.StatusBadRequest, GRPCInvalidArgument},
+	CodeSupervisorCycle:            {http.StatusBadRequest, GRPCInvalidArgument},
+	CodePermissionDenied:           {http.StatusForbidden, GRPCPermissionDenied},
+	CodeSlotNotFound:               {http.StatusNotFound, GRPCNotFound},
+	CodeSlotOutOfService:           {http.StatusConflict, GRPCFailedPrecondition},
+	CodeInvalidThreshold:           {http.StatusBadRequest, GRPCInvalidArgument},
+	CodeEventBusClosed:             {http.StatusServiceUnavailable, GRPCUnavailable},
+	CodeWebhookDeliveryFailed:      {http.StatusBadGateway, GRPCUnavailable},
+	CodeEmailDeliveryFailed:        {http.StatusBadGateway, GRPCUnavailable},
+	CodeInvalidStateLog:            {http.StatusUnprocessableEntity, GRPCFailedPrecondition},
+	CodeParkingLotInEmergency:      {http.StatusServiceUnavailable, GRPCUnavailable},
+	CodeNoEmergency:                {http.StatusConflict, GRPCFailedPrecondition},
+	CodeInvalidSnapshot:            {http.StatusBadRequest, GRPCInvalidArgument},
+	CodeUnsupportedSnapshotVersion: {http.StatusBadRequest, GRPCFailedPrecondition},
+}
+
+func (code ErrorCode) HTTPStatus() int {
+	if mapping, ok := statusMappings[code]; ok {
+		return mapping.http
+	}
+	return http.StatusInternalServerError
+}
+
+func (code ErrorCode) GRPCStatus() GRPCCode {
+	if mapping, ok := statusMappings[code]; ok {
+		return mapping.grpc
+	}
+	return GRPCInternal
+}
@@ -0,0 +1,139 @@
+package Exceptions
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ParkingError is the error type returned by every failure path in the
+// parking domain. Two ParkingErrors match under errors.Is when their codes
+// match, so sentinels stay usable after context has been attached.
+type ParkingError struct {
+	Code               ErrorCode
+	Message            string
+	ParkingLotId       int
+	SlotAddress        string
+	TicketId           string
+	RegistrationNumber string
+	Cause              error
+}
+
+func newParkingError(code ErrorCode, message string) *ParkingError {
+	return &ParkingError{Code: code, Message: message}
+}
+
+// Error is the message followed by whatever context is attached, so the
+// lot, slot, ticket or car involved survive into logs.
+func (e *ParkingError) Error() string {
+	parts := e.context()
+	if len(parts) == 0 {
+		return e.Message
+	}
+	return fmt.Sprintf("%s (%s)", e.Message, strings.Join(parts, ", "))
+}
+
+func (e *ParkingError) Unwrap() error {
+	return e.Cause
+}
+
+func (e *ParkingError) Is(target error) bool {
+	other, ok := target.(*ParkingError)
+	return ok && other.Code == e.Code
+}
+
+// Detail renders the error together with its code.
+func (e *ParkingError) Detail() string {
+	return fmt.Sprintf("[%s] %s", e.Code, e.Error())
+}
+
+func (e *ParkingError) context() []string {
+	parts := []string{}
+	if e.ParkingLotId != 0 {
+		parts = append(parts, fmt.Sprintf("lot=%d", e.ParkingLotId))
+	}
+	if e.SlotAddress != "" {
+		parts = append(parts, fmt.Sprintf("slot=%s", e.SlotAddress))
+	}
+	if e.TicketId != "" {
+		parts = append(parts, fmt.Sprintf("ticket=%s", e.TicketId))
+	}
+	if e.RegistrationNumber != "" {
+		parts = append(parts, fmt.Sprintf("registration=%s", e.RegistrationNumber))
+	}
+	if e.Cause != nil {
+		parts = append(parts, fmt.Sprintf("cause=%s", e.Cause.Error()))
+	}
+	return parts
+}
+
+func (e *ParkingError) HTTPStatus() int {
+	return e.Code.HTTPStatus()
+}
+
+func (e *ParkingError) GRPCStatus() GRPCCode {
+	return e.Code.GRPCStatus()
+}
+
+func (e *ParkingError) WithLot(parkingLotId int) *ParkingError {
+	copied := *e
+	copied.ParkingLotId = parkingLotId
+	return &copied
+}
+
+func (e *ParkingError) WithSlot(slotAddress fmt.Stringer) *ParkingError {
+	copied := *e
+	copied.SlotAddress = slotAddress.String()
+	return &copied
+}
+
+func (e *ParkingError) WithTicket(ticketId string) *ParkingError {
+	copied := *e
+	copied.TicketId = ticketId
+	return &copied
+}
+
+func (e *ParkingError) WithRegistration(registrationNumber string) *ParkingError {
+	copied := *e
+	copied.RegistrationNumber = registrationNumber
+	return &copied
+}
+
+// Wrap attaches the lower level error that caused this one. Context already
+// present on the cause is carried over unless this error sets its own.
+func (e *ParkingError) Wrap(cause error) *ParkingError {
+	copied := *e
+	copied.Cause = cause
+	var inner *ParkingError
+	if errors.As(cause, &inner) {
+		if copied.ParkingLotId == 0 {
+			copied.ParkingLotId = inner.ParkingLotId
+		}
+		if copied.SlotAddress == "" {
+			copied.SlotAddress = inner.SlotAddress
+		}
+		if copied.TicketId == "" {
+			copied.TicketId = inner.TicketId
+		}
+		if copied.RegistrationNumber == "" {
+			copied.RegistrationNumber = inner.RegistrationNumber
+		}
+	}
+	return &copied
+}
+
+func CodeOf(err error) ErrorCode {
+	var parkingError *ParkingError
+	if errors.As(err, &parkingError) {
+		return parkingError.Code
+	}
+	return CodeUnknown
+}
+
+func HTTPStatusOf(err error) int {
+	return CodeOf(err).HTTPStatus()
+}
+
+func GRPCStatusOf(err error) GRPCCode {
+	return CodeOf(err).GRPCStatus()
+}
@@ -0,0 +1,139 @@
+package Implementations
+
+import (
+	"ParkingLot_go/Exceptions"
+	"time"
+)
+
+type AccessDecision struct {
+	Principal    string
+	Permission   Permission
+	ParkingLotId int
+	Allowed      bool
+	At           time.Time
+}
+
+// AccessControl maps roles to permissions and keeps an audit log of every
+// check it makes, allowed or denied.
+type AccessControl struct {
+	clock           Clock
+	rolePermissions map[Role][]Permission
+	auditLog        []AccessDecision
+}
+
+func AccessControlConstruct(clock Clock) *AccessControl {
+	if clock == nil {
+		clock = SystemClock{}
+	}
+	return &AccessControl{
+		clock: clock,
+		rolePermissions: map[Role][]Permission{
+			RoleOwner: {
+				PermissionCreateLot,
+				PermissionAssignAttendent,
+				PermissionPark,
+				PermissionUnpark,
+				PermissionQueryRegistration,
+				PermissionViewReports,
+				PermissionManageLot,
+			},
+			RoleSupervisor: {PermissionPark, PermissionUnpark, PermissionQueryRegistration, PermissionViewReports},
+			RoleAttendent:  {PermissionPark, PermissionUnpark, PermissionQueryRegistration},
+			RolePolice:     {PermissionQueryRegistration, PermissionViewReports},
+		},
+		auditLog: []AccessDecision{},
+	}
+}
+
+func (accessControl *AccessControl) Grant(role Role, permission Permission) {
+	if accessControl.allows(role, permission) {
+		return
+	}
+	accessControl.rolePermissions[role] = append(accessControl.rolePermissions[role], permission)
+}
+
+func (accessControl *AccessControl) Revoke(role Role, permission Permission) {
+	remaining := []Permission{}
+	for _, granted := range accessControl.rolePermissions[role] {
+		if granted != permission {
+			remaining = append(remaining, granted)
+		}
+	}
+	accessControl.rolePermissions[role] = remaining
+}
+
+// Check records the decision in the audit log and returns a permission
+// denied error when none of the principal's roles grants the permission at
+// the lot.
+func (accessControl *AccessControl) Check(principal *Principal, permission Permission, parkingLotId int) error {
+	allowed := accessControl.decide(principal, permission, func(role Role) bool {
+		return principal.grants(role, parkingLotId)
+	})
+	accessControl.audit(principal, permission, parkingLotId, allowed)
+	if !allowed {
+		return Exceptions.ErrPermissionDenied.WithLot(parkingLotId)
+	}
+	return nil
+}
+
+// CheckGlobal is Check for an operation that is not about any lot, such as
+// creating one. The role alone grants it; its audit entry has no lot.
+func (accessControl *AccessControl) CheckGlobal(principal *Principal, permission Permission) error {
+	allowed := accessControl.decide(principal, permission, func(Role) bool { return true })
+	accessControl.audit(principal, permission, 0, allowed)
+	if !allowed {
+		return Exceptions.ErrPermissionDenied
+	}
+	return nil
+}
+
+func (accessControl *AccessControl) decide(principal *Principal, permission Permission, inScope func(role Role) bool) bool {
+	if principal == nil {
+		return false
+	}
+	for _, role := range principal.Roles {
+		if accessControl.allows(role, permission) && inScope(role) {
+			return true
+		}
+	}
+	return false
+}
+
+func (accessControl *AccessControl) audit(principal *Principal, permission Permission, parkingLotId int, allowed bool) {
+	accessControl.auditLog = append(accessControl.auditLog, AccessDecision{
+		Principal:    principal.displayName(),
+		Permission:   permission,
+		ParkingLotId: parkingLotId,
+		Allowed:      allowed,
+		At:           accessControl.clock.Now(),
+	})
+}
+
+func (accessControl *AccessControl) AuditLog() []AccessDecision {
+	return append([]AccessDecision{}, accessControl.auditLog...)
+}
+
+func (accessControl *AccessControl) allows(role Role, permission Permission) bool {
+	for _, granted := range accessControl.rolePermissions[role] {
+		if granted == permission {
+			return true
+		}
+	}
+	return false
+}
+
+// authorize is the nil-safe check used by the domain types; without access
+// control every operation is allowed.
+func authorize(accessControl *AccessControl, principal *Principal, permission Permission, parkingLotId int) error {
+	if accessControl == nil {
+		return nil
+	}
+	return accessControl.Check(principal, permission, parkingLotId)
+}
+
+func authorizeGlobal(accessControl *AccessControl, principal *Principal, permission Permission) error {
+	if accessControl == nil {
+		return nil
+	}
+	return accessControl.CheckGlobal(principal, permission)
+}
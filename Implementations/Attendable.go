@@ -1,8 +1,17 @@
 package Implementations
 
 type Attendable interface {
-	Assign(parkingLot *ParkingLot) error
+	Assign(parkingLot *ParkingLot, owner *Owner) error
 	Park(car *Car) (*Ticket, error)
 	CheckIfCarIsAlreadyParked(car *Car) error
 	Unpark(ticket *Ticket) (*Car, error)
+	HoldsTicket(ticket *Ticket) bool
+	IsAvailable() bool
+	AssignedLots() []*ParkingLot
+	ParkedCarsView() []*Car
 }
+
+var (
+	_ Attendable = (*Attendent)(nil)
+	_ Attendable = (*Supervisor)(nil)
+)
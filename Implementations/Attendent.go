@@ -1,12 +1,37 @@
 package Implementations
 
-import "errors"
+import (
+	"ParkingLot_go/Exceptions"
+	"errors"
+	"github.com/google/uuid"
+	"sort"
+	"time"
+)
 
 type Attendent struct {
+	Id                  string
 	AssignedParkingLots []*ParkingLot
 	ParkedCars          []*Car
 	NextLotStrategy     NextLotStrategy
 	AssignedOwner       *Owner
+	DistanceToEntrance  int
+	Contracts           []*Contract
+	Clock               Clock
+	Principal           *Principal
+	decisions           map[string]*ParkingDecision
+	tickets             map[string]*attendedCar
+	roster              *Roster
+	activity            map[*Owner]*OwnerActivity
+	metrics             *AttendentMetrics
+	retrievalRequests   map[string]time.Time
+}
+
+// attendedCar is the attendent's own record of a car it parked, used to route
+// unparks and to check the bookkeeping against the lots.
+type attendedCar struct {
+	car    *Car
+	ticket *Ticket
+	lot    *ParkingLot
 }
 
 func AttendentConstruct(strategy NextLotStrategy) *Attendent {
@@ -14,6 +39,8 @@ func AttendentConstruct(strategy NextLotStrategy) *Attendent {
 		AssignedParkingLots: []*ParkingLot{},
 		ParkedCars:          []*Car{},
 		NextLotStrategy:     strategy,
+		decisions:           map[string]*ParkingDecision{},
+		tickets:             map[string]*attendedCar{},
 	}
 }
 
@@ -22,65 +49,629 @@ func AttendentConstructDefault() *Attendent {
 		AssignedParkingLots: []*ParkingLot{},
 		ParkedCars:          []*Car{},
 		NextLotStrategy:     &NormalNextLotStrategy{},
+		decisions:           map[string]*ParkingDecision{},
+		tickets:             map[string]*attendedCar{},
 	}
 }
 
+// Assign gives the attendent a lot. Owners other than the attendent's
+// employer may only assign lots while holding a contract with it; such lots
+// join that contract.
 func (attendent *Attendent) Assign(parkingLot *ParkingLot, owner *Owner) error {
-	if attendent.AssignedOwner != nil && attendent.AssignedOwner != owner {
-		return errors.New("this parking lot is not owned by the owner")
+	tx := newParkingTransaction()
+	if err := attendent.assign(parkingLot, owner, tx); err != nil {
+		return err
+	}
+	tx.commit()
+	return nil
+}
+
+func (attendent *Attendent) assign(parkingLot *ParkingLot, owner *Owner, tx *parkingTransaction) error {
+	contract := attendent.contractWith(owner)
+	if attendent.AssignedOwner != nil && attendent.AssignedOwner != owner && contract == nil {
+		return Exceptions.ErrParkingLotNotOwned.WithLot(parkingLot.GetParkingLotId())
 	}
 	for _, lot := range attendent.AssignedParkingLots {
 		if lot == parkingLot {
-			return errors.New("parking lot already assigned")
+			return Exceptions.ErrParkingLotAlreadyAssigned.WithLot(parkingLot.GetParkingLotId())
 		}
 	}
-	if attendent.AssignedOwner == nil {
+	if attendent.AssignedOwner == nil && contract == nil {
 		attendent.AssignedOwner = owner
+		tx.onRollback(func() { attendent.AssignedOwner = nil })
 	}
+	if contract != nil && !contract.covers(parkingLot) {
+		covered := contract.ParkingLots
+		contract.ParkingLots = append(contract.ParkingLots, parkingLot)
+		tx.onRollback(func() { contract.ParkingLots = covered })
+	}
+	attendent.addLot(parkingLot, tx)
+	return nil
+}
+
+// identity names the attendent in the state log, making up an ID the first
+// time it is needed.
+func (attendent *Attendent) identity() string {
+	if attendent.Id == "" {
+		attendent.Id = uuid.NewString()
+	}
+	return attendent.Id
+}
+
+func (attendent *Attendent) addLot(parkingLot *ParkingLot, tx *parkingTransaction) {
+	lots := attendent.AssignedParkingLots
+	scoped := attendent.Principal.hasLot(parkingLot.GetParkingLotId())
 	attendent.AssignedParkingLots = append(attendent.AssignedParkingLots, parkingLot)
+	attendent.Principal.addLot(parkingLot.GetParkingLotId())
+	tx.onRollback(func() {
+		attendent.AssignedParkingLots = lots
+		if !scoped {
+			attendent.Principal.removeLot(parkingLot.GetParkingLotId())
+		}
+	})
+	tx.afterCommit(func() { parkingLot.stateLog.record(attendent.lotChange(ChangeAttendentAssigned, parkingLot)) })
+}
+
+func (attendent *Attendent) lotChange(changeType ChangeType, parkingLot *ParkingLot) StateChange {
+	change := parkingLot.lotChange(changeType)
+	change.AttendentId = attendent.identity()
+	return change
+}
+
+func (attendent *Attendent) contractWith(owner *Owner) *Contract {
+	for i := len(attendent.Contracts) - 1; i >= 0; i-- {
+		if attendent.Contracts[i].Owner == owner {
+			return attendent.Contracts[i]
+		}
+	}
 	return nil
 }
 
+func (attendent *Attendent) endContractsWith(owner *Owner) {
+	remaining := []*Contract{}
+	for _, contract := range attendent.Contracts {
+		if contract.Owner != owner {
+			remaining = append(remaining, contract)
+		}
+	}
+	attendent.Contracts = remaining
+}
+
+func (attendent *Attendent) now() time.Time {
+	if attendent.Clock == nil {
+		return time.Now()
+	}
+	return attendent.Clock.Now()
+}
+
+// inScope reports whether the attendent may work the lot right now. Lots not
+// covered by any contract belong to the attendent's employer and are always
+// in scope; contracted lots only while one of their contracts is active.
+func (attendent *Attendent) inScope(parkingLot *ParkingLot) bool {
+	covered := false
+	now := attendent.now()
+	for _, contract := range attendent.Contracts {
+		if contract.covers(parkingLot) {
+			covered = true
+			if contract.IsActive(now) {
+				return true
+			}
+		}
+	}
+	return !covered
+}
+
+// lotsInScope returns the assigned lots the attendent may park in now,
+// limited to the given owner's lots unless owner is nil.
+func (attendent *Attendent) lotsInScope(owner *Owner) []*ParkingLot {
+	lots := []*ParkingLot{}
+	for _, lot := range attendent.AssignedParkingLots {
+		if owner != nil && lot.Owner != owner {
+			continue
+		}
+		if attendent.inScope(lot) {
+			lots = append(lots, lot)
+		}
+	}
+	return lots
+}
+
 func (attendent *Attendent) Park(car *Car) (*Ticket, error) {
+	ticket, _, err := attendent.ParkWithExplanation(car)
+	return ticket, err
+}
+
+// ParkFor parks the car in one of the given owner's lots only.
+func (attendent *Attendent) ParkFor(owner *Owner, car *Car) (*Ticket, error) {
+	ticket, _, err := attendent.parkFor(owner, car)
+	return ticket, err
+}
+
+// ParkWithExplanation parks the car like Park and also returns the decision
+// record describing which lots were considered and why the slot was chosen.
+func (attendent *Attendent) ParkWithExplanation(car *Car) (*Ticket, *ParkingDecision, error) {
+	return attendent.parkFor(nil, car)
+}
+
+func (attendent *Attendent) parkFor(owner *Owner, car *Car) (*Ticket, *ParkingDecision, error) {
+	ticket, decision, err := attendent.attemptPark(owner, car)
+	if err != nil {
+		attendent.Metrics().recordFailure(attendent.now(), err)
+	}
+	return ticket, decision, err
+}
+
+func (attendent *Attendent) attemptPark(owner *Owner, car *Car) (*Ticket, *ParkingDecision, error) {
+	decision := &ParkingDecision{
+		Car:       *car,
+		Strategy:  strategyName(attendent.NextLotStrategy),
+		DecidedAt: attendent.now(),
+	}
+	if attendent.roster != nil && !attendent.roster.IsOnDuty(attendent) {
+		return nil, decision, Exceptions.ErrAttendentOffShift.WithRegistration(car.RegistrationNumber)
+	}
 	if len(attendent.AssignedParkingLots) == 0 {
-		return nil, errors.New("no parking lot assigned")
+		return nil, decision, Exceptions.ErrNoParkingLotAssigned.WithRegistration(car.RegistrationNumber)
 	}
 	if err := attendent.CheckIfCarIsAlreadyParked(car); err != nil {
-		return nil, err
+		return nil, decision, err
+	}
+
+	scopedLots := attendent.lotsInScope(owner)
+	if len(scopedLots) == 0 {
+		decision.Candidates = attendent.describeCandidates(car, scopedLots, nil)
+		return nil, decision, Exceptions.ErrContractNotActive.WithRegistration(car.RegistrationNumber)
+	}
+	openLots := openParkingLots(scopedLots)
+	if len(openLots) == 0 {
+		decision.Candidates = attendent.describeCandidates(car, scopedLots, nil)
+		return nil, decision, Exceptions.ErrParkingLotInEmergency.WithRegistration(car.RegistrationNumber)
+	}
+
+	selectedLot, err := attendent.NextLotStrategy.GetNextLot(openLots)
+	decision.Candidates = attendent.describeCandidates(car, scopedLots, selectedLot)
+	if err != nil {
+		return nil, decision, err
+	}
+	if err := authorize(selectedLot.accessControl, attendent.Principal, PermissionPark, selectedLot.GetParkingLotId()); err != nil {
+		decision.rejectSelected(selectedLot, err)
+		return nil, decision, err
 	}
 
-	selectedLot, err := attendent.NextLotStrategy.GetNextLot(attendent.AssignedParkingLots)
+	tx := newParkingTransaction()
+	ticket, err := selectedLot.park(car, attendent.Principal, tx)
 	if err != nil {
+		tx.rollback()
+		decision.rejectSelected(selectedLot, err)
+		return nil, decision, err
+	}
+	attendent.track(&attendedCar{car: car, ticket: ticket, lot: selectedLot}, tx)
+	decision.TicketId = ticket.GetTicketId()
+	decision.SelectedParkingLotId = selectedLot.GetParkingLotId()
+	decision.Slot, err = selectedLot.SlotAddressOf(ticket)
+	if err != nil {
+		tx.rollback()
+		return nil, decision, err
+	}
+	decision.SlotReason = "nearest free slot"
+	attendent.recordDecision(decision, tx)
+	if attendent.roster != nil {
+		tx.afterCommit(func() { attendent.roster.recordPark(attendent) })
+	}
+	tx.afterCommit(func() {
+		attendent.recordActivity(selectedLot, true)
+		attendent.Metrics().recordPark(attendent.now(), selectedLot.GetParkingLotId())
+	})
+	tx.commit()
+	return ticket, decision, nil
+}
+
+func (attendent *Attendent) describeCandidates(car *Car, scopedLots []*ParkingLot, selectedLot *ParkingLot) []LotCandidate {
+	candidates := make([]LotCandidate, 0, len(attendent.AssignedParkingLots))
+	for _, lot := range attendent.AssignedParkingLots {
+		candidate := LotCandidate{ParkingLotId: lot.GetParkingLotId()}
+		switch {
+		case !contains(scopedLots, lot):
+			candidate.Outcome = CandidateFiltered
+			candidate.Reason = "not in scope"
+		case lot == selectedLot:
+			candidate.Outcome = CandidateSelected
+			candidate.Reason = "chosen by strategy"
+		case lot.InEmergency():
+			candidate.Outcome = CandidateFiltered
+			candidate.Reason = "closed for an emergency"
+		case lot.IsFull():
+			candidate.Outcome = CandidateFull
+			candidate.Reason = "no free slots"
+		case lot.IsCarAlreadyParked(*car):
+			candidate.Outcome = CandidateIncompatible
+			candidate.Reason = "car is already parked in this lot"
+		default:
+			candidate.Outcome = CandidateFiltered
+			candidate.Reason = "not preferred by strategy"
+		}
+		candidates = append(candidates, candidate)
+	}
+	return candidates
+}
+
+func (attendent *Attendent) recordDecision(decision *ParkingDecision, tx *parkingTransaction) {
+	if attendent.decisions == nil {
+		attendent.decisions = map[string]*ParkingDecision{}
+	}
+	attendent.decisions[decision.TicketId] = decision
+	tx.onRollback(func() { delete(attendent.decisions, decision.TicketId) })
+}
+
+// authorizeReport checks that the principal may view reports at every lot the
+// attendent works, as its reports cover them all.
+func (attendent *Attendent) authorizeReport(principal *Principal) error {
+	for _, lot := range attendent.AssignedParkingLots {
+		if err := authorize(lot.accessControl, principal, PermissionViewReports, lot.GetParkingLotId()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Explain returns the decision recorded when the car for this ticket was parked.
+func (attendent *Attendent) Explain(ticketId string) (*ParkingDecision, error) {
+	decision, ok := attendent.decisions[ticketId]
+	if !ok {
+		return nil, Exceptions.ErrDecisionNotFound.WithTicket(ticketId)
+	}
+	return decision, nil
+}
+
+// ExplainAs is Explain for a principal, who must be allowed to view reports at
+// every lot the attendent works.
+func (attendent *Attendent) ExplainAs(principal *Principal, ticketId string) (*ParkingDecision, error) {
+	if err := attendent.authorizeReport(principal); err != nil {
+		return nil, err
+	}
+	return attendent.Explain(ticketId)
+}
+
+// recordActivity attributes a park or unpark to the owner of the lot, along
+// with the contract fee for parks made under contract.
+func (attendent *Attendent) recordActivity(parkingLot *ParkingLot, parked bool) {
+	if attendent.activity == nil {
+		attendent.activity = map[*Owner]*OwnerActivity{}
+	}
+	activity, ok := attendent.activity[parkingLot.Owner]
+	if !ok {
+		activity = &OwnerActivity{}
+		attendent.activity[parkingLot.Owner] = activity
+	}
+	if !parked {
+		activity.CarsUnparked++
+		return
+	}
+	activity.CarsParked++
+	now := attendent.now()
+	for _, contract := range attendent.Contracts {
+		if contract.covers(parkingLot) && contract.IsActive(now) {
+			activity.Revenue += contract.FeePerPark
+			return
+		}
+	}
+}
+
+func (attendent *Attendent) Metrics() *AttendentMetrics {
+	if attendent.metrics == nil {
+		attendent.metrics = &AttendentMetrics{}
+	}
+	return attendent.metrics
+}
+
+func (attendent *Attendent) MetricsAs(principal *Principal) (*AttendentMetrics, error) {
+	if err := attendent.authorizeReport(principal); err != nil {
 		return nil, err
 	}
+	return attendent.Metrics(), nil
+}
+
+// RequestRetrieval notes when the driver asked for the car, so the time until
+// it is delivered by Unpark can be measured. Cars unparked without a request
+// still count as retrieved but have no retrieval time. A pending request
+// follows the car if another attendent takes it over.
+func (attendent *Attendent) RequestRetrieval(ticket *Ticket) error {
+	if !attendent.HoldsTicket(ticket) {
+		return Exceptions.ErrCarNotFound.WithTicket(ticketIdOf(ticket))
+	}
+	if attendent.retrievalRequests == nil {
+		attendent.retrievalRequests = map[string]time.Time{}
+	}
+	attendent.retrievalRequests[ticket.GetTicketId()] = attendent.now()
+	return nil
+}
+
+func (attendent *Attendent) recordRetrieval(ticketId string, parkingLot *ParkingLot) {
+	requestedAt := attendent.retrievalRequests[ticketId]
+	delete(attendent.retrievalRequests, ticketId)
+	attendent.Metrics().recordRetrieval(requestedAt, attendent.now(), parkingLot.GetParkingLotId())
+}
+
+func (attendent *Attendent) ActivityFor(owner *Owner) OwnerActivity {
+	if activity, ok := attendent.activity[owner]; ok {
+		return *activity
+	}
+	return OwnerActivity{}
+}
+
+// ActivityForAs is ActivityFor for a principal. It is the owner's report,
+// checked under the owner's access control.
+func (attendent *Attendent) ActivityForAs(principal *Principal, owner *Owner) (OwnerActivity, error) {
+	if err := authorizeGlobal(owner.accessControl, principal, PermissionViewReports); err != nil {
+		return OwnerActivity{}, err
+	}
+	return attendent.ActivityFor(owner), nil
+}
+
+// IsAvailable reports whether the attendent is on duty and has a lot with room.
+func (attendent *Attendent) IsAvailable() bool {
+	return attendent.isAvailableFor(nil)
+}
+
+func (attendent *Attendent) isAvailableFor(owner *Owner) bool {
+	if attendent.roster != nil && !attendent.roster.IsOnDuty(attendent) {
+		return false
+	}
+	for _, lot := range openParkingLots(attendent.lotsInScope(owner)) {
+		if !lot.IsFull() {
+			return true
+		}
+	}
+	return false
+}
+
+// openParkingLots leaves out lots closed for an emergency.
+func openParkingLots(parkingLots []*ParkingLot) []*ParkingLot {
+	open := []*ParkingLot{}
+	for _, lot := range parkingLots {
+		if !lot.InEmergency() {
+			open = append(open, lot)
+		}
+	}
+	return open
+}
+
+func (attendent *Attendent) AssignedLots() []*ParkingLot {
+	return append([]*ParkingLot{}, attendent.AssignedParkingLots...)
+}
+
+func (attendent *Attendent) ParkedCarsView() []*Car {
+	return append([]*Car{}, attendent.ParkedCars...)
+}
 
-	ticket, _ := selectedLot.Park(car)
-	attendent.ParkedCars = append(attendent.ParkedCars, car) // Remember to add parked car
-	return ticket, nil
+func (attendent *Attendent) HoldsTicket(ticket *Ticket) bool {
+	_, ok := attendent.tickets[ticketIdOf(ticket)]
+	return ok
 }
 
 func (attendent *Attendent) CheckIfCarIsAlreadyParked(car *Car) error {
 	for _, parkedCar := range attendent.ParkedCars {
 		if parkedCar == car {
-			return errors.New("car already assigned to this parking lot")
+			return Exceptions.ErrCarAlreadyAssigned.WithRegistration(car.RegistrationNumber)
 		}
 	}
 	return nil
 }
 
 func (attendent *Attendent) Unpark(ticket *Ticket) (*Car, error) {
+	attended, ok := attendent.tickets[ticketIdOf(ticket)]
+	if !ok {
+		attended = attendent.locate(ticket)
+	}
+	if attended == nil {
+		return nil, Exceptions.ErrCarNotFound.WithTicket(ticketIdOf(ticket))
+	}
+
+	if !attendent.inScope(attended.lot) {
+		return nil, Exceptions.ErrContractNotActive.WithLot(attended.lot.GetParkingLotId()).WithTicket(ticketIdOf(ticket))
+	}
+	if err := authorize(attended.lot.accessControl, attendent.Principal, PermissionUnpark, attended.lot.GetParkingLotId()); err != nil {
+		return nil, err
+	}
+
+	tx := newParkingTransaction()
+	unparkedCar, err := attended.lot.unpark(ticket, attendent.Principal, tx)
+	if err != nil {
+		tx.rollback()
+		return nil, Exceptions.ErrCarNotFound.Wrap(err)
+	}
+	attendent.untrack(attended, tx)
+	if attendent.roster != nil {
+		tx.afterCommit(func() { attendent.roster.recordUnpark(attendent) })
+	}
+	tx.afterCommit(func() {
+		attendent.recordActivity(attended.lot, false)
+		attendent.recordRetrieval(ticketIdOf(ticket), attended.lot)
+	})
+	tx.commit()
+	return unparkedCar, nil
+}
+
+// locate finds a ticket this attendent did not issue but that belongs to one
+// of its lots, such as a car parked directly at the lot.
+func (attendent *Attendent) locate(ticket *Ticket) *attendedCar {
 	for _, lot := range attendent.AssignedParkingLots {
-		unparkedCar, err := lot.Unpark(ticket)
-		if err == nil {
-			// Remove the car from the parked cars list
-			for i, parkedCar := range attendent.ParkedCars {
-				if parkedCar == unparkedCar {
-					attendent.ParkedCars = append(attendent.ParkedCars[:i], attendent.ParkedCars[i+1:]...)
-					break
-				}
+		if car, ok := lot.carFor(ticket); ok {
+			return &attendedCar{car: car, ticket: ticket, lot: lot}
+		}
+	}
+	return nil
+}
+
+func (attendent *Attendent) track(attended *attendedCar, tx *parkingTransaction) {
+	if attendent.tickets == nil {
+		attendent.tickets = map[string]*attendedCar{}
+	}
+	ticketId := attended.ticket.GetTicketId()
+	attendent.tickets[ticketId] = attended
+	attendent.ParkedCars = append(attendent.ParkedCars, attended.car)
+	tx.onRollback(func() {
+		delete(attendent.tickets, ticketId)
+		attendent.removeParkedCar(attended.car)
+	})
+	tx.afterCommit(func() { attended.lot.stateLog.record(attendent.attendedChange(attended)) })
+}
+
+func (attendent *Attendent) attendedChange(attended *attendedCar) StateChange {
+	change := attendent.lotChange(ChangeCarAttended, attended.lot)
+	change.TicketId = attended.ticket.GetTicketId()
+	change.Vehicle = *attended.car
+	return change
+}
+
+func (attendent *Attendent) untrack(attended *attendedCar, tx *parkingTransaction) {
+	ticketId := attended.ticket.GetTicketId()
+	if _, ok := attendent.tickets[ticketId]; !ok {
+		return
+	}
+	delete(attendent.tickets, ticketId)
+	attendent.removeParkedCar(attended.car)
+	tx.onRollback(func() {
+		attendent.tickets[ticketId] = attended
+		attendent.ParkedCars = append(attendent.ParkedCars, attended.car)
+	})
+}
+
+// handOverTo moves every tracked car and ticket to the incoming attendent and
+// returns the ticket IDs that changed hands.
+func (attendent *Attendent) handOverTo(incoming *Attendent, tx *parkingTransaction) []string {
+	ticketIds := []string{}
+	for ticketId, attended := range attendent.tickets {
+		incoming.adopt(attended, attendent, tx)
+		ticketIds = append(ticketIds, ticketId)
+	}
+	tickets, parkedCars := attendent.tickets, attendent.ParkedCars
+	attendent.tickets = map[string]*attendedCar{}
+	attendent.ParkedCars = []*Car{}
+	tx.onRollback(func() {
+		attendent.tickets = tickets
+		attendent.ParkedCars = parkedCars
+	})
+	sort.Strings(ticketIds)
+	return ticketIds
+}
+
+// releaseLot drops the lot from the attendent and returns the cars it was
+// holding there so someone else can take responsibility for them.
+func (attendent *Attendent) releaseLot(parkingLot *ParkingLot, tx *parkingTransaction) []*attendedCar {
+	for i, lot := range attendent.AssignedParkingLots {
+		if lot == parkingLot {
+			lots := attendent.AssignedParkingLots
+			attendent.AssignedParkingLots = append(append([]*ParkingLot{}, lots[:i]...), lots[i+1:]...)
+			unscoped := false
+			if parkingLot.Owner == nil || attendent.Principal != parkingLot.Owner.Principal {
+				unscoped = attendent.Principal.hasLot(parkingLot.GetParkingLotId())
+				attendent.Principal.removeLot(parkingLot.GetParkingLotId())
 			}
-			return unparkedCar, nil
+			tx.onRollback(func() {
+				attendent.AssignedParkingLots = lots
+				if unscoped {
+					attendent.Principal.addLot(parkingLot.GetParkingLotId())
+				}
+			})
+			tx.afterCommit(func() { parkingLot.stateLog.record(attendent.lotChange(ChangeAttendentReleased, parkingLot)) })
+			break
+		}
+	}
+	released := []*attendedCar{}
+	for _, attended := range attendent.tickets {
+		if attended.lot == parkingLot {
+			released = append(released, attended)
+		}
+	}
+	for _, attended := range released {
+		attendent.untrack(attended, tx)
+	}
+	sort.Slice(released, func(i, j int) bool {
+		return released[i].ticket.GetTicketId() < released[j].ticket.GetTicketId()
+	})
+	return released
+}
+
+// takeOverLot moves the previous attendent's cars in the lot to this one and
+// returns their ticket IDs. The lot itself is added directly, so callers
+// check ownership first.
+func (attendent *Attendent) takeOverLot(previous *Attendent, parkingLot *ParkingLot, tx *parkingTransaction) []string {
+	if !contains(attendent.AssignedParkingLots, parkingLot) {
+		attendent.addLot(parkingLot, tx)
+	}
+	ticketIds := []string{}
+	for _, attended := range previous.releaseLot(parkingLot, tx) {
+		attendent.adopt(attended, previous, tx)
+		ticketIds = append(ticketIds, attended.ticket.GetTicketId())
+	}
+	return ticketIds
+}
+
+// recontract puts a lot the attendent already works under a contract with
+// its new owner.
+func (attendent *Attendent) recontract(parkingLot *ParkingLot, contract *Contract, tx *parkingTransaction) {
+	contracts := attendent.Contracts
+	attendent.Contracts = append(attendent.Contracts, contract)
+	tx.onRollback(func() { attendent.Contracts = contracts })
+	if !contains(attendent.AssignedParkingLots, parkingLot) {
+		attendent.addLot(parkingLot, tx)
+	}
+}
+
+// adopt takes responsibility for a car another attendent parked, keeping the
+// original parking decision so it can still be explained.
+func (attendent *Attendent) adopt(attended *attendedCar, previous *Attendent, tx *parkingTransaction) {
+	attendent.track(attended, tx)
+	ticketId := attended.ticket.GetTicketId()
+	if decision, ok := previous.decisions[ticketId]; ok {
+		attendent.recordDecision(decision, tx)
+	}
+	if requestedAt, ok := previous.retrievalRequests[ticketId]; ok {
+		if attendent.retrievalRequests == nil {
+			attendent.retrievalRequests = map[string]time.Time{}
 		}
+		attendent.retrievalRequests[ticketId] = requestedAt
+		delete(previous.retrievalRequests, ticketId)
+		tx.onRollback(func() {
+			delete(attendent.retrievalRequests, ticketId)
+			previous.retrievalRequests[ticketId] = requestedAt
+		})
+	}
+}
+
+func (attendent *Attendent) removeParkedCar(car *Car) {
+	for i, parkedCar := range attendent.ParkedCars {
+		if parkedCar == car {
+			attendent.ParkedCars = append(attendent.ParkedCars[:i], attendent.ParkedCars[i+1:]...)
+			return
+		}
+	}
+}
+
+// Validate compares the attendent's bookkeeping with the slots of its lots and
+// reports every ticket or car the two disagree on.
+func (attendent *Attendent) Validate() error {
+	var problems []error
+	for ticketId, attended := range attendent.tickets {
+		if !contains(attendent.AssignedParkingLots, attended.lot) {
+			problems = append(problems, Exceptions.ErrInconsistentState.WithTicket(ticketId).WithLot(attended.lot.GetParkingLotId()))
+			continue
+		}
+		car, ok := attended.lot.carFor(attended.ticket)
+		if !ok || *car != *attended.car {
+			problems = append(problems, Exceptions.ErrInconsistentState.WithTicket(ticketId).WithLot(attended.lot.GetParkingLotId()).WithRegistration(attended.car.RegistrationNumber))
+		}
+	}
+	if len(attendent.ParkedCars) != len(attendent.tickets) {
+		problems = append(problems, Exceptions.ErrInconsistentState)
+	}
+	return errors.Join(problems...)
+}
+
+func (attendent *Attendent) ValidateAs(principal *Principal) error {
+	if err := attendent.authorizeReport(principal); err != nil {
+		return err
 	}
-	return nil, errors.New("car not found")
+	return attendent.Validate()
 }
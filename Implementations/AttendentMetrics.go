@@ -0,0 +1,110 @@
+package Implementations
+
+import (
+	"ParkingLot_go/Exceptions"
+	"sort"
+	"time"
+)
+
+type parkRecord struct {
+	at           time.Time
+	parkingLotId int
+}
+
+// retrievalRecord has a zero requestedAt when the car was unparked without a
+// retrieval request, so it counts as retrieved but is not timed.
+type retrievalRecord struct {
+	requestedAt  time.Time
+	deliveredAt  time.Time
+	parkingLotId int
+}
+
+type failureRecord struct {
+	at   time.Time
+	code Exceptions.ErrorCode
+}
+
+// AttendentMetrics keeps the raw activity of one attendent so it can be
+// summarised over any time window.
+type AttendentMetrics struct {
+	parks      []parkRecord
+	retrievals []retrievalRecord
+	failures   []failureRecord
+}
+
+type MetricsSummary struct {
+	From                 time.Time
+	To                   time.Time
+	CarsParked           int
+	CarsRetrieved        int
+	ParkedPerHour        float64
+	RetrievedPerHour     float64
+	RetrievalTimes       *Histogram
+	AverageRetrievalTime time.Duration
+	FailedParks          int
+	FailedParksByReason  map[Exceptions.ErrorCode]int
+	LotsServed           []int
+}
+
+func (metrics *AttendentMetrics) recordPark(at time.Time, parkingLotId int) {
+	metrics.parks = append(metrics.parks, parkRecord{at: at, parkingLotId: parkingLotId})
+}
+
+func (metrics *AttendentMetrics) recordRetrieval(requestedAt time.Time, deliveredAt time.Time, parkingLotId int) {
+	metrics.retrievals = append(metrics.retrievals, retrievalRecord{requestedAt: requestedAt, deliveredAt: deliveredAt, parkingLotId: parkingLotId})
+}
+
+func (metrics *AttendentMetrics) recordFailure(at time.Time, err error) {
+	metrics.failures = append(metrics.failures, failureRecord{at: at, code: Exceptions.CodeOf(err)})
+}
+
+// Summary covers activity at or after from and before to.
+func (metrics *AttendentMetrics) Summary(from time.Time, to time.Time) MetricsSummary {
+	summary := MetricsSummary{
+		From:                from,
+		To:                  to,
+		RetrievalTimes:      HistogramConstruct(DefaultRetrievalBuckets),
+		FailedParksByReason: map[Exceptions.ErrorCode]int{},
+		LotsServed:          []int{},
+	}
+	within := func(at time.Time) bool {
+		return !at.Before(from) && at.Before(to)
+	}
+	lotsServed := map[int]bool{}
+	for _, park := range metrics.parks {
+		if within(park.at) {
+			summary.CarsParked++
+			lotsServed[park.parkingLotId] = true
+		}
+	}
+	for _, retrieval := range metrics.retrievals {
+		if within(retrieval.deliveredAt) {
+			summary.CarsRetrieved++
+			if !retrieval.requestedAt.IsZero() {
+				summary.RetrievalTimes.Observe(retrieval.deliveredAt.Sub(retrieval.requestedAt))
+			}
+			lotsServed[retrieval.parkingLotId] = true
+		}
+	}
+	for _, failure := range metrics.failures {
+		if within(failure.at) {
+			summary.FailedParks++
+			summary.FailedParksByReason[failure.code]++
+		}
+	}
+	for parkingLotId := range lotsServed {
+		summary.LotsServed = append(summary.LotsServed, parkingLotId)
+	}
+	sort.Ints(summary.LotsServed)
+	summary.AverageRetrievalTime = summary.RetrievalTimes.Mean()
+	if hours := to.Sub(from).Hours(); hours > 0 {
+		summary.ParkedPerHour = float64(summary.CarsParked) / hours
+		summary.RetrievedPerHour = float64(summary.CarsRetrieved) / hours
+	}
+	return summary
+}
+
+type AttendentPerformance struct {
+	Attendent *Attendent
+	Summary   MetricsSummary
+}
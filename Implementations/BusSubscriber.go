@@ -0,0 +1,200 @@
+package Implementations
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"os"
+	"sync"
+	"time"
+)
+
+type SubscriberStats struct {
+	Delivered  int
+	Retried    int
+	Dropped    int
+	Spilled    int
+	DeadLetter int
+	Pending    int
+}
+
+// BusSubscriber is one subscriber's queue and the worker that drains it.
+// Once the queue has spilled to disk, later events follow them there so that
+// delivery order is kept.
+type BusSubscriber struct {
+	bus       *EventBus
+	handler   EventHandler
+	options   SubscriberOptions
+	mutex     sync.Mutex
+	changed   *sync.Cond
+	queue     []Event
+	spillPath string
+	spilled   int
+	closed    bool
+	stats     SubscriberStats
+}
+
+func newBusSubscriber(bus *EventBus, handler EventHandler, options SubscriberOptions) (*BusSubscriber, error) {
+	subscriber := &BusSubscriber{
+		bus:     bus,
+		handler: handler,
+		options: options,
+		queue:   []Event{},
+	}
+	subscriber.changed = sync.NewCond(&subscriber.mutex)
+	if options.Overflow == SpillToDisk {
+		spillFile, err := os.CreateTemp(options.SpillDirectory, "events-*.jsonl")
+		if err != nil {
+			return nil, err
+		}
+		subscriber.spillPath = spillFile.Name()
+		spillFile.Close()
+	}
+	return subscriber, nil
+}
+
+func (subscriber *BusSubscriber) Stats() SubscriberStats {
+	subscriber.mutex.Lock()
+	defer subscriber.mutex.Unlock()
+	stats := subscriber.stats
+	stats.Pending = len(subscriber.queue) + subscriber.spilled
+	return stats
+}
+
+func (subscriber *BusSubscriber) enqueue(event Event) {
+	subscriber.mutex.Lock()
+	defer subscriber.mutex.Unlock()
+	switch subscriber.options.Overflow {
+	case Block:
+		for len(subscriber.queue) >= subscriber.options.BufferSize && !subscriber.closed {
+			subscriber.changed.Wait()
+		}
+	case SpillToDisk:
+		if subscriber.spilled > 0 || len(subscriber.queue) >= subscriber.options.BufferSize {
+			if subscriber.spill(event) == nil {
+				subscriber.changed.Broadcast()
+				return
+			}
+		}
+	default:
+		if len(subscriber.queue) >= subscriber.options.BufferSize {
+			subscriber.queue = subscriber.queue[1:]
+			subscriber.stats.Dropped++
+		}
+	}
+	subscriber.queue = append(subscriber.queue, event)
+	subscriber.changed.Broadcast()
+}
+
+func (subscriber *BusSubscriber) spill(event Event) error {
+	spillFile, err := os.OpenFile(subscriber.spillPath, os.O_APPEND|os.O_WRONLY, 0o600)
+	if err != nil {
+		return err
+	}
+	defer spillFile.Close()
+	if err := json.NewEncoder(spillFile).Encode(event); err != nil {
+		return err
+	}
+	subscriber.spilled++
+	subscriber.stats.Spilled++
+	return nil
+}
+
+// unspill moves spilled events back into memory once the queue is empty.
+func (subscriber *BusSubscriber) unspill() {
+	spillFile, err := os.Open(subscriber.spillPath)
+	if err != nil {
+		return
+	}
+	scanner := bufio.NewScanner(spillFile)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		var event Event
+		if json.Unmarshal(scanner.Bytes(), &event) == nil {
+			subscriber.queue = append(subscriber.queue, event)
+		}
+	}
+	spillFile.Close()
+	os.Truncate(subscriber.spillPath, 0)
+	subscriber.spilled = 0
+}
+
+func (subscriber *BusSubscriber) next() (Event, bool) {
+	subscriber.mutex.Lock()
+	defer subscriber.mutex.Unlock()
+	for len(subscriber.queue) == 0 && subscriber.spilled == 0 && !subscriber.closed {
+		subscriber.changed.Wait()
+	}
+	if len(subscriber.queue) == 0 && subscriber.spilled > 0 {
+		subscriber.unspill()
+	}
+	if len(subscriber.queue) == 0 {
+		return Event{}, false
+	}
+	event := subscriber.queue[0]
+	subscriber.queue = subscriber.queue[1:]
+	subscriber.changed.Broadcast()
+	return event, true
+}
+
+func (subscriber *BusSubscriber) run() {
+	defer subscriber.bus.workers.Done()
+	for {
+		event, ok := subscriber.next()
+		if !ok {
+			break
+		}
+		subscriber.deliver(event)
+	}
+	if subscriber.spillPath != "" {
+		os.Remove(subscriber.spillPath)
+	}
+}
+
+// deliver retries a failing handler with exponential backoff and hands the
+// event to the dead-letter queue once it runs out of attempts or the handler
+// reports a permanent failure.
+func (subscriber *BusSubscriber) deliver(event Event) {
+	backoff := subscriber.options.InitialBackoff
+	var err error
+	attempt := 1
+	for ; attempt <= subscriber.options.MaxAttempts; attempt++ {
+		if err = subscriber.handler.Handle(event); err == nil {
+			subscriber.record(func(stats *SubscriberStats) { stats.Delivered++ })
+			return
+		}
+		if attempt == subscriber.options.MaxAttempts || errors.As(err, &permanentError{}) {
+			break
+		}
+		subscriber.record(func(stats *SubscriberStats) { stats.Retried++ })
+		time.Sleep(backoff)
+		backoff *= 2
+		if backoff > subscriber.options.MaxBackoff {
+			backoff = subscriber.options.MaxBackoff
+		}
+	}
+	subscriber.record(func(stats *SubscriberStats) { stats.DeadLetter++ })
+	subscriber.bus.deadLetter(DeadLetter{
+		Subscriber: subscriber.options.Name,
+		Event:      event,
+		Err:        err,
+		Attempts:   attempt,
+		At:         time.Now(),
+	})
+	if abandoner, ok := subscriber.handler.(AbandonHandler); ok {
+		abandoner.Abandon(event)
+	}
+}
+
+func (subscriber *BusSubscriber) record(update func(stats *SubscriberStats)) {
+	subscriber.mutex.Lock()
+	defer subscriber.mutex.Unlock()
+	update(&subscriber.stats)
+}
+
+func (subscriber *BusSubscriber) close() {
+	subscriber.mutex.Lock()
+	defer subscriber.mutex.Unlock()
+	subscriber.closed = true
+	subscriber.changed.Broadcast()
+}
@@ -8,6 +8,7 @@ type Car struct {
 	RegistrationNumber string
 	Color              Enums.Color
 	LicensePlate       string
+	VehicleType        Enums.VehicleType
 }
 
 func NewCar(registrationNumber string, color Enums.Color) Car {
@@ -25,6 +26,14 @@ func (c *Car) IsColor(color Enums.Color) bool {
 	return c.Color == color
 }
 
+// TypeOfVehicle treats cars created without a vehicle type as plain cars.
+func (c *Car) TypeOfVehicle() Enums.VehicleType {
+	if c.VehicleType == "" {
+		return Enums.CAR
+	}
+	return c.VehicleType
+}
+
 func (c *Car) HasRegistrationNumber(registrationNumber string) bool {
 	return c.RegistrationNumber == registrationNumber
 }
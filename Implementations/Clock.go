@@ -0,0 +1,13 @@
+package Implementations
+
+import "time"
+
+type Clock interface {
+	Now() time.Time
+}
+
+type SystemClock struct{}
+
+func (clock SystemClock) Now() time.Time {
+	return time.Now()
+}
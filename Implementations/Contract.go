@@ -0,0 +1,32 @@
+package Implementations
+
+import "time"
+
+// Contract lets an attendent work the listed lots of an owner other than its
+// employer during the contract period, at a fee per car parked. Fees are in
+// minor currency units so revenue adds up exactly.
+type Contract struct {
+	Owner       *Owner
+	ParkingLots []*ParkingLot
+	Start       time.Time
+	End         time.Time
+	FeePerPark  int64
+}
+
+func (contract *Contract) IsActive(at time.Time) bool {
+	return !at.Before(contract.Start) && at.Before(contract.End)
+}
+
+func (contract *Contract) overlaps(start time.Time, end time.Time) bool {
+	return start.Before(contract.End) && contract.Start.Before(end)
+}
+
+func (contract *Contract) covers(parkingLot *ParkingLot) bool {
+	return contains(contract.ParkingLots, parkingLot)
+}
+
+type OwnerActivity struct {
+	CarsParked   int
+	CarsUnparked int
+	Revenue      int64
+}
@@ -0,0 +1,262 @@
+package Implementations
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+type DeliveryMode string
+
+const (
+	Immediate    DeliveryMode = "IMMEDIATE"
+	HourlyDigest DeliveryMode = "HOURLY_DIGEST"
+	DailyDigest  DeliveryMode = "DAILY_DIGEST"
+)
+
+// QuietHours runs from Start to End, both offsets from local midnight in
+// Location, and may wrap past midnight. A nil Location means UTC.
+type QuietHours struct {
+	Start    time.Duration
+	End      time.Duration
+	Location *time.Location
+}
+
+func (quietHours *QuietHours) contains(at time.Time) bool {
+	if quietHours == nil || quietHours.Start == quietHours.End {
+		return false
+	}
+	location := quietHours.Location
+	if location == nil {
+		location = time.UTC
+	}
+	local := at.In(location)
+	sinceMidnight := time.Duration(local.Hour())*time.Hour + time.Duration(local.Minute())*time.Minute + time.Duration(local.Second())*time.Second
+	if quietHours.Start < quietHours.End {
+		return sinceMidnight >= quietHours.Start && sinceMidnight < quietHours.End
+	}
+	return sinceMidnight >= quietHours.Start || sinceMidnight < quietHours.End
+}
+
+type DeliveryPreferences struct {
+	Mode       DeliveryMode
+	QuietHours *QuietHours
+}
+
+type LotDigest struct {
+	ParkingLotId   int
+	ParkingLotName string
+	TimesFilled    int
+	PeakOccupancy  float64
+	TimeFull       time.Duration
+	Events         int
+}
+
+type Digest struct {
+	From        time.Time
+	To          time.Time
+	Lots        []LotDigest
+	EventCounts map[EventType]int
+}
+
+func (digest Digest) String() string {
+	lines := []string{fmt.Sprintf("%s to %s", digest.From.Format(time.RFC3339), digest.To.Format(time.RFC3339))}
+	for _, lot := range digest.Lots {
+		lines = append(lines, fmt.Sprintf("parking lot with ID %d filled %d times, peak %.0f%%, full for %s", lot.ParkingLotId, lot.TimesFilled, lot.PeakOccupancy, lot.TimeFull))
+	}
+	return strings.Join(lines, "; ")
+}
+
+// DigestReceiver is implemented by targets that can take a digest. Targets
+// that cannot are sent nothing when a digest is due.
+type DigestReceiver interface {
+	ReceiveDigest(digest Digest)
+}
+
+type lotTally struct {
+	digest    LotDigest
+	fullSince time.Time
+}
+
+// DigestNotifier sits in front of a subscriber and applies its delivery
+// preferences. Critical events always go straight through. Everything else
+// is delivered as it happens or rolled into a digest, and is held for a
+// digest during quiet hours. Digests are sent by Tick or by the next event
+// once their period is over and quiet hours have ended.
+type DigestNotifier struct {
+	Target      Notifiable
+	Preferences DeliveryPreferences
+	Clock       Clock
+	mutex       sync.Mutex
+	periodStart time.Time
+	tallies     map[int]*lotTally
+	eventCounts map[EventType]int
+}
+
+func DigestNotifierConstruct(target Notifiable, preferences DeliveryPreferences, clock Clock) *DigestNotifier {
+	if clock == nil {
+		clock = SystemClock{}
+	}
+	if preferences.Mode == "" {
+		preferences.Mode = Immediate
+	}
+	return &DigestNotifier{
+		Target:      target,
+		Preferences: preferences,
+		Clock:       clock,
+		tallies:     map[int]*lotTally{},
+		eventCounts: map[EventType]int{},
+	}
+}
+
+func (notifier *DigestNotifier) Notify(event Event) {
+	notifier.mutex.Lock()
+	now := notifier.Clock.Now()
+	digest, due := notifier.due(now)
+	deliverNow := event.Level() == Critical || (notifier.Preferences.Mode == Immediate && !notifier.Preferences.QuietHours.contains(now))
+	if !deliverNow {
+		notifier.tally(event)
+	}
+	notifier.mutex.Unlock()
+	if due {
+		notifier.sendDigest(digest)
+	}
+	if deliverNow {
+		notifier.Target.Notify(event)
+	}
+}
+
+// Tick sends the digest if one is due.
+func (notifier *DigestNotifier) Tick() {
+	notifier.mutex.Lock()
+	digest, due := notifier.due(notifier.Clock.Now())
+	notifier.mutex.Unlock()
+	if due {
+		notifier.sendDigest(digest)
+	}
+}
+
+func (notifier *DigestNotifier) sendDigest(digest Digest) {
+	if receiver, ok := notifier.Target.(DigestReceiver); ok {
+		receiver.ReceiveDigest(digest)
+	}
+}
+
+// boundary is the start of the digest period that at falls in.
+func (notifier *DigestNotifier) boundary(at time.Time) time.Time {
+	switch notifier.Preferences.Mode {
+	case HourlyDigest:
+		return at.Truncate(time.Hour)
+	case DailyDigest:
+		location := time.UTC
+		if notifier.Preferences.QuietHours != nil && notifier.Preferences.QuietHours.Location != nil {
+			location = notifier.Preferences.QuietHours.Location
+		}
+		local := at.In(location)
+		return time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, location)
+	}
+	return at
+}
+
+func (notifier *DigestNotifier) periodEnd() time.Time {
+	start := notifier.boundary(notifier.periodStart)
+	switch notifier.Preferences.Mode {
+	case HourlyDigest:
+		return start.Add(time.Hour)
+	case DailyDigest:
+		return time.Date(start.Year(), start.Month(), start.Day()+1, 0, 0, 0, 0, start.Location())
+	}
+	return notifier.periodStart
+}
+
+func (notifier *DigestNotifier) due(now time.Time) (Digest, bool) {
+	if notifier.periodStart.IsZero() {
+		notifier.periodStart = now
+		return Digest{}, false
+	}
+	if notifier.Preferences.QuietHours.contains(now) {
+		return Digest{}, false
+	}
+	if notifier.Preferences.Mode != Immediate && now.Before(notifier.periodEnd()) {
+		return Digest{}, false
+	}
+	// A period held back by quiet hours closes at the current boundary, so
+	// the overdue periods go out as one digest rather than one per event.
+	return notifier.flush(notifier.boundary(now))
+}
+
+func (notifier *DigestNotifier) tally(event Event) {
+	notifier.eventCounts[event.Type]++
+	tally, ok := notifier.tallies[event.ParkingLotId]
+	if !ok {
+		tally = &lotTally{digest: LotDigest{ParkingLotId: event.ParkingLotId}}
+		notifier.tallies[event.ParkingLotId] = tally
+	}
+	if event.ParkingLotName != "" {
+		tally.digest.ParkingLotName = event.ParkingLotName
+	}
+	tally.digest.Events++
+	tally.digest.PeakOccupancy = max(tally.digest.PeakOccupancy, event.OccupancyPercent)
+	switch event.Type {
+	case LotFull:
+		tally.digest.TimesFilled++
+		tally.fullSince = event.At
+	case LotAvailable:
+		if !tally.fullSince.IsZero() {
+			tally.digest.TimeFull += max(event.At.Sub(tally.fullSince), 0)
+			tally.fullSince = time.Time{}
+		}
+	}
+}
+
+// flush closes the current period at end. In digest modes lots that are
+// still full carry into the next period so their time full keeps counting.
+func (notifier *DigestNotifier) flush(end time.Time) (Digest, bool) {
+	digest := Digest{From: notifier.periodStart, To: end, Lots: []LotDigest{}, EventCounts: notifier.eventCounts}
+	carried := map[int]*lotTally{}
+	for parkingLotId, tally := range notifier.tallies {
+		if !tally.fullSince.IsZero() {
+			tally.digest.TimeFull += max(end.Sub(tally.fullSince), 0)
+		}
+		if !tally.fullSince.IsZero() && notifier.Preferences.Mode != Immediate {
+			fullSince := end
+			if tally.fullSince.After(end) {
+				fullSince = tally.fullSince
+			}
+			carried[parkingLotId] = &lotTally{
+				digest:    LotDigest{ParkingLotId: parkingLotId, ParkingLotName: tally.digest.ParkingLotName, PeakOccupancy: 100},
+				fullSince: fullSince,
+			}
+		}
+		digest.Lots = append(digest.Lots, tally.digest)
+	}
+	sort.Slice(digest.Lots, func(i, j int) bool { return digest.Lots[i].ParkingLotId < digest.Lots[j].ParkingLotId })
+	notifier.periodStart = end
+	notifier.tallies = carried
+	notifier.eventCounts = map[EventType]int{}
+	return digest, len(digest.Lots) > 0
+}
+
+// console prints events and digests for whoever owns it, in the owner's
+// locale and time zone.
+type console struct {
+	name      string
+	recipient *Recipient
+}
+
+func (console console) Notify(event Event) {
+	fmt.Printf("%s notified: %s\n", console.name, renderMessage(DefaultCatalog, event, console.recipientOrDefault()))
+}
+
+func (console console) ReceiveDigest(digest Digest) {
+	fmt.Printf("%s digest: %s\n", console.name, renderDigest(DefaultCatalog, digest, console.recipientOrDefault()))
+}
+
+func (console console) recipientOrDefault() Recipient {
+	if console.recipient == nil {
+		return Recipient{}
+	}
+	return *console.recipient
+}
@@ -0,0 +1,11 @@
+package Implementations
+
+type DispatchStrategy interface {
+	SelectAttendent(availableAttendents []*Attendent, car *Car) (*Attendent, error)
+}
+
+// rosterDispatchStrategy is implemented by strategies that choose from the
+// whole roster rather than only the attendents available right now.
+type rosterDispatchStrategy interface {
+	selectFromRoster(roster []*Attendent, isAvailable func(attendent *Attendent) bool, car *Car) (*Attendent, error)
+}
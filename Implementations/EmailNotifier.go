@@ -0,0 +1,218 @@
+package Implementations
+
+import (
+	"ParkingLot_go/Exceptions"
+	"bytes"
+	"crypto/tls"
+	"fmt"
+	"mime"
+	"net"
+	"net/smtp"
+	"strconv"
+	"strings"
+	"text/template"
+	"time"
+)
+
+type SMTPConfig struct {
+	Host      string
+	Port      int
+	Username  string
+	Password  string
+	StartTLS  bool
+	TLSConfig *tls.Config
+	PoolSize  int
+	Timeout   time.Duration
+}
+
+// EmailNotifier renders each event through the message catalog in the
+// recipient's locale and time zone, or through its own subject and body
+// templates when they are set, and sends it over SMTP, reusing up to PoolSize
+// open connections. Like a webhook it is an EventHandler, not a Notifiable:
+// subscribe it to an EventBus, which sends off the parking path and retries
+// the delivery errors Handle returns. Every exchange with the server must
+// finish within Config.Timeout.
+type EmailNotifier struct {
+	Config          SMTPConfig
+	From            string
+	To              []string
+	Recipient       Recipient
+	Catalog         *MessageCatalog
+	SubjectTemplate *template.Template
+	BodyTemplate    *template.Template
+	Clock           Clock
+	pool            chan *smtpConnection
+}
+
+// smtpConnection keeps the connection under the client so each send can be
+// given a deadline.
+type smtpConnection struct {
+	client *smtp.Client
+	conn   net.Conn
+}
+
+func (connection *smtpConnection) extendDeadline(timeout time.Duration) error {
+	return connection.conn.SetDeadline(time.Now().Add(timeout))
+}
+
+func EmailNotifierConstruct(config SMTPConfig, from string, to ...string) *EmailNotifier {
+	if config.PoolSize <= 0 {
+		config.PoolSize = 2
+	}
+	if config.Timeout <= 0 {
+		config.Timeout = 10 * time.Second
+	}
+	return &EmailNotifier{
+		Config: config,
+		From:   from,
+		To:     append([]string{}, to...),
+		pool:   make(chan *smtpConnection, config.PoolSize),
+	}
+}
+
+func (notifier *EmailNotifier) Handle(event Event) error {
+	message, err := notifier.render(event)
+	if err != nil {
+		return Exceptions.ErrEmailDeliveryFailed.Wrap(err)
+	}
+	connection, err := notifier.acquire()
+	if err != nil {
+		return Exceptions.ErrEmailDeliveryFailed.Wrap(err)
+	}
+	if err := notifier.send(connection, message); err != nil {
+		connection.client.Close()
+		return Exceptions.ErrEmailDeliveryFailed.Wrap(err)
+	}
+	notifier.release(connection)
+	return nil
+}
+
+// Close ends every pooled connection.
+func (notifier *EmailNotifier) Close() {
+	for {
+		select {
+		case connection := <-notifier.pool:
+			connection.extendDeadline(notifier.Config.Timeout)
+			connection.client.Quit()
+		default:
+			return
+		}
+	}
+}
+
+func (notifier *EmailNotifier) now() time.Time {
+	if notifier.Clock == nil {
+		return time.Now()
+	}
+	return notifier.Clock.Now()
+}
+
+func (notifier *EmailNotifier) render(event Event) ([]byte, error) {
+	var subject, body bytes.Buffer
+	message := renderMessage(notifier.Catalog, event, notifier.Recipient)
+	subject.WriteString(message)
+	body.WriteString(message + "\n")
+	if notifier.SubjectTemplate != nil {
+		subject.Reset()
+		if err := notifier.SubjectTemplate.Execute(&subject, event); err != nil {
+			return nil, err
+		}
+	}
+	if notifier.BodyTemplate != nil {
+		body.Reset()
+		if err := notifier.BodyTemplate.Execute(&body, event); err != nil {
+			return nil, err
+		}
+	}
+	var email bytes.Buffer
+	fmt.Fprintf(&email, "From: %s\r\n", notifier.From)
+	fmt.Fprintf(&email, "To: %s\r\n", strings.Join(notifier.To, ", "))
+	fmt.Fprintf(&email, "Subject: %s\r\n", mime.QEncoding.Encode("UTF-8", strings.TrimSpace(subject.String())))
+	fmt.Fprintf(&email, "Date: %s\r\n", notifier.now().Format(time.RFC1123Z))
+	email.WriteString("MIME-Version: 1.0\r\n")
+	email.WriteString("Content-Type: text/plain; charset=UTF-8\r\n\r\n")
+	email.WriteString(strings.ReplaceAll(strings.ReplaceAll(body.String(), "\r\n", "\n"), "\n", "\r\n"))
+	return email.Bytes(), nil
+}
+
+// acquire reuses a pooled connection when it still answers, otherwise dials.
+func (notifier *EmailNotifier) acquire() (*smtpConnection, error) {
+	for {
+		select {
+		case connection := <-notifier.pool:
+			if connection.extendDeadline(notifier.Config.Timeout) == nil && connection.client.Reset() == nil {
+				return connection, nil
+			}
+			connection.client.Close()
+		default:
+			return notifier.dial()
+		}
+	}
+}
+
+func (notifier *EmailNotifier) release(connection *smtpConnection) {
+	select {
+	case notifier.pool <- connection:
+	default:
+		connection.client.Quit()
+	}
+}
+
+func (notifier *EmailNotifier) dial() (*smtpConnection, error) {
+	address := net.JoinHostPort(notifier.Config.Host, strconv.Itoa(notifier.Config.Port))
+	conn, err := net.DialTimeout("tcp", address, notifier.Config.Timeout)
+	if err != nil {
+		return nil, err
+	}
+	if err := conn.SetDeadline(time.Now().Add(notifier.Config.Timeout)); err != nil {
+		conn.Close()
+		return nil, err
+	}
+	client, err := smtp.NewClient(conn, notifier.Config.Host)
+	if err != nil {
+		conn.Close()
+		return nil, err
+	}
+	if notifier.Config.StartTLS {
+		tlsConfig := notifier.Config.TLSConfig
+		if tlsConfig == nil {
+			tlsConfig = &tls.Config{ServerName: notifier.Config.Host}
+		}
+		if err := client.StartTLS(tlsConfig); err != nil {
+			client.Close()
+			return nil, err
+		}
+	}
+	if notifier.Config.Username != "" {
+		auth := smtp.PlainAuth("", notifier.Config.Username, notifier.Config.Password, notifier.Config.Host)
+		if err := client.Auth(auth); err != nil {
+			client.Close()
+			return nil, err
+		}
+	}
+	return &smtpConnection{client: client, conn: conn}, nil
+}
+
+func (notifier *EmailNotifier) send(connection *smtpConnection, message []byte) error {
+	if err := connection.extendDeadline(notifier.Config.Timeout); err != nil {
+		return err
+	}
+	client := connection.client
+	if err := client.Mail(notifier.From); err != nil {
+		return err
+	}
+	for _, recipient := range notifier.To {
+		if err := client.Rcpt(recipient); err != nil {
+			return err
+		}
+	}
+	writer, err := client.Data()
+	if err != nil {
+		return err
+	}
+	if _, err := writer.Write(message); err != nil {
+		writer.Close()
+		return err
+	}
+	return writer.Close()
+}
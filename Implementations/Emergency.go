@@ -0,0 +1,200 @@
+package Implementations
+
+import (
+	"ParkingLot_go/Exceptions"
+	"time"
+)
+
+// EvacuatingVehicle is a car that was in the lot when the emergency was
+// declared. LeftAt stays zero until it has driven out.
+type EvacuatingVehicle struct {
+	Vehicle  Car
+	Slot     SlotAddress
+	TicketId string
+	ParkedAt time.Time
+	LeftAt   time.Time
+}
+
+func (vehicle EvacuatingVehicle) HasLeft() bool {
+	return !vehicle.LeftAt.IsZero()
+}
+
+// Emergency is a lot's lockdown. While it is active the lot takes no cars,
+// and the cars inside are tracked until they leave.
+type Emergency struct {
+	ParkingLotId int
+	Reason       string
+	DeclaredAt   time.Time
+	LiftedAt     time.Time
+	vehicles     []*EvacuatingVehicle
+}
+
+func (emergency *Emergency) IsActive() bool {
+	return emergency.LiftedAt.IsZero()
+}
+
+// Vehicles is the responders' list: every car that was parked when the
+// emergency began, in slot order, with the ones that have left marked.
+func (emergency *Emergency) Vehicles() []EvacuatingVehicle {
+	vehicles := make([]EvacuatingVehicle, 0, len(emergency.vehicles))
+	for _, vehicle := range emergency.vehicles {
+		vehicles = append(vehicles, *vehicle)
+	}
+	return vehicles
+}
+
+// Remaining lists the cars still in the lot.
+func (emergency *Emergency) Remaining() []EvacuatingVehicle {
+	remaining := []EvacuatingVehicle{}
+	for _, vehicle := range emergency.vehicles {
+		if !vehicle.HasLeft() {
+			remaining = append(remaining, *vehicle)
+		}
+	}
+	return remaining
+}
+
+func (emergency *Emergency) IsEvacuated() bool {
+	return len(emergency.Remaining()) == 0
+}
+
+func (parkinglot *ParkingLot) InEmergency() bool {
+	return parkinglot.emergency != nil
+}
+
+// Emergency returns the lot's emergency in progress, or nil.
+func (parkinglot *ParkingLot) Emergency() *Emergency {
+	return parkinglot.emergency
+}
+
+// declareEmergency closes the lot and tells every subscriber, whatever its
+// filter. A lot already in an emergency keeps the one it has.
+func (parkinglot *ParkingLot) declareEmergency(reason string, actor *Principal) *Emergency {
+	if parkinglot.emergency != nil {
+		return parkinglot.emergency
+	}
+	emergency := &Emergency{
+		ParkingLotId: parkinglot.ParkingLotId,
+		Reason:       reason,
+		DeclaredAt:   parkinglot.now(),
+		vehicles:     []*EvacuatingVehicle{},
+	}
+	for _, slot := range parkinglot.slots {
+		if slot.IsFree() {
+			continue
+		}
+		emergency.vehicles = append(emergency.vehicles, &EvacuatingVehicle{
+			Vehicle:  *slot.car,
+			Slot:     parkinglot.addressOf(slot),
+			TicketId: slot.ticket.GetTicketId(),
+			ParkedAt: slot.parkedAt,
+		})
+	}
+	parkinglot.emergency = emergency
+	parkinglot.broadcast(parkinglot.emergencyEvent(EmergencyDeclared, actor))
+	if emergency.IsEvacuated() {
+		parkinglot.broadcast(parkinglot.emergencyEvent(LotEvacuated, actor))
+	}
+	return emergency
+}
+
+func (parkinglot *ParkingLot) liftEmergency(actor *Principal) error {
+	if parkinglot.emergency == nil {
+		return Exceptions.ErrNoEmergency.WithLot(parkinglot.ParkingLotId)
+	}
+	event := parkinglot.emergencyEvent(EmergencyLifted, actor)
+	parkinglot.emergency.LiftedAt = event.At
+	parkinglot.emergency = nil
+	parkinglot.broadcast(event)
+	return nil
+}
+
+func (parkinglot *ParkingLot) emergencyEvent(eventType EventType, actor *Principal) Event {
+	event := parkinglot.lotEvent(eventType)
+	event.Actor = actor.displayName()
+	event.Reason = parkinglot.emergency.Reason
+	return event
+}
+
+// trackDeparture crosses a car that has just left off the emergency's list.
+// departure is the event published for the car leaving.
+func (parkinglot *ParkingLot) trackDeparture(departure Event) {
+	emergency := parkinglot.emergency
+	if emergency == nil {
+		return
+	}
+	for _, vehicle := range emergency.vehicles {
+		if vehicle.TicketId != departure.TicketId || vehicle.HasLeft() {
+			continue
+		}
+		vehicle.LeftAt = departure.At
+		evacuated := departure
+		evacuated.Type = VehicleEvacuated
+		evacuated.Reason = emergency.Reason
+		evacuated.ParkedAt = vehicle.ParkedAt
+		parkinglot.publish(evacuated)
+		if emergency.IsEvacuated() {
+			lotEvacuated := parkinglot.emergencyEvent(LotEvacuated, nil)
+			lotEvacuated.Actor = departure.Actor
+			parkinglot.broadcast(lotEvacuated)
+		}
+		return
+	}
+}
+
+// DeclareEmergency locks the lot down: it refuses new cars until the
+// emergency is lifted, and everyone subscribed to it is alerted.
+func (owner *Owner) DeclareEmergency(parkingLot *ParkingLot, reason string) (*Emergency, error) {
+	if err := owner.manage(parkingLot); err != nil {
+		return nil, err
+	}
+	return parkingLot.declareEmergency(reason, owner.Principal), nil
+}
+
+// DeclareEmergencyAtAllLots locks down every lot the owner has, and any lot
+// it creates until the emergency is lifted.
+func (owner *Owner) DeclareEmergencyAtAllLots(reason string) ([]*Emergency, error) {
+	if err := owner.authorizeGlobal(PermissionManageLot); err != nil {
+		return nil, err
+	}
+	owner.emergencyReason = &reason
+	emergencies := []*Emergency{}
+	for _, lot := range owner.OwnerParkingLots {
+		emergencies = append(emergencies, lot.declareEmergency(reason, owner.Principal))
+	}
+	return emergencies, nil
+}
+
+func (owner *Owner) LiftEmergency(parkingLot *ParkingLot) error {
+	if err := owner.manage(parkingLot); err != nil {
+		return err
+	}
+	return parkingLot.liftEmergency(owner.Principal)
+}
+
+// LiftEmergencyAtAllLots reopens every lot of the owner that is in an
+// emergency.
+func (owner *Owner) LiftEmergencyAtAllLots() error {
+	if err := owner.authorizeGlobal(PermissionManageLot); err != nil {
+		return err
+	}
+	owner.emergencyReason = nil
+	for _, lot := range owner.OwnerParkingLots {
+		if lot.InEmergency() {
+			lot.liftEmergency(owner.Principal)
+		}
+	}
+	return nil
+}
+
+// EvacuationList gathers the cars still inside every lot of the owner that is
+// in an emergency, for responders.
+func (owner *Owner) EvacuationList() []EvacuatingVehicle {
+	remaining := []EvacuatingVehicle{}
+	for _, lot := range owner.OwnerParkingLots {
+		if lot.InEmergency() {
+			remaining = append(remaining, lot.emergency.Remaining()...)
+		}
+	}
+	return remaining
+}
@@ -0,0 +1,98 @@
+package Implementations
+
+import "time"
+
+type EventType string
+
+const (
+	LotFull          EventType = "LOT_FULL"
+	LotAvailable     EventType = "LOT_AVAILABLE"
+	CarParked        EventType = "CAR_PARKED"
+	CarUnparked      EventType = "CAR_UNPARKED"
+	SlotOutOfService EventType = "SLOT_OUT_OF_SERVICE"
+	SlotInService    EventType = "SLOT_IN_SERVICE"
+	TicketVoided     EventType = "TICKET_VOIDED"
+
+	OccupancyAboveThreshold EventType = "OCCUPANCY_ABOVE_THRESHOLD"
+	OccupancyBelowThreshold EventType = "OCCUPANCY_BELOW_THRESHOLD"
+
+	WatchlistMatched  EventType = "WATCHLIST_MATCHED"
+	VehicleOverstayed EventType = "VEHICLE_OVERSTAYED"
+
+	EmergencyDeclared EventType = "EMERGENCY_DECLARED"
+	VehicleEvacuated  EventType = "VEHICLE_EVACUATED"
+	LotEvacuated      EventType = "LOT_EVACUATED"
+	EmergencyLifted   EventType = "EMERGENCY_LIFTED"
+
+	AttendentRevoked EventType = "ATTENDENT_REVOKED"
+)
+
+// Event is something that happened at a parking lot. Slot, Vehicle and
+// TicketId are only set for events about a particular slot or car, and Actor
+// names the principal that caused it. OccupancyPercent is the lot's occupancy
+// once the change has been made. AttendentId, SuccessorId and TicketIds are
+// only set when an attendent loses a lot: who lost it, who took its cars over
+// and their tickets.
+type Event struct {
+	Type             EventType
+	At               time.Time
+	ParkingLotId     int
+	ParkingLotName   string
+	Slot             SlotAddress
+	Vehicle          *Car
+	TicketId         string
+	Actor            string
+	OccupancyPercent float64
+	Threshold        float64
+	Stage            OverstayStage
+	ParkedAt         time.Time
+	Reason           string
+	AttendentId      string
+	SuccessorId      string
+	TicketIds        []string
+}
+
+type EventLevel int
+
+const (
+	Info EventLevel = iota
+	Warning
+	Critical
+)
+
+func (level EventLevel) String() string {
+	switch level {
+	case Warning:
+		return "WARNING"
+	case Critical:
+		return "CRITICAL"
+	}
+	return "INFO"
+}
+
+func (event Event) Level() EventLevel {
+	switch event.Type {
+	case LotFull, OccupancyAboveThreshold, SlotOutOfService, TicketVoided, LotEvacuated, EmergencyLifted:
+		return Warning
+	case WatchlistMatched, EmergencyDeclared:
+		return Critical
+	case VehicleOverstayed:
+		if event.Stage == TowEligible {
+			return Critical
+		}
+		return Warning
+	}
+	return Info
+}
+
+func (event Event) registrationNumber() string {
+	if event.Vehicle == nil {
+		return ""
+	}
+	return event.Vehicle.RegistrationNumber
+}
+
+// String renders the event in English with times in UTC.
+func (event Event) String() string {
+	return renderMessage(DefaultCatalog, event, Recipient{Locale: English})
+}
@@ -0,0 +1,186 @@
+package Implementations
+
+import (
+	"ParkingLot_go/Exceptions"
+	"context"
+	"sync"
+	"time"
+)
+
+type OverflowPolicy string
+
+const (
+	DropOldest  OverflowPolicy = "DROP_OLDEST"
+	Block       OverflowPolicy = "BLOCK"
+	SpillToDisk OverflowPolicy = "SPILL_TO_DISK"
+)
+
+// EventHandler is a subscriber whose delivery can fail and be retried.
+type EventHandler interface {
+	Handle(event Event) error
+}
+
+// AbandonHandler is implemented by handlers that keep state for an event
+// while the bus retries it. The bus calls Abandon once it gives the event up
+// to the dead-letter queue.
+type AbandonHandler interface {
+	Abandon(event Event)
+}
+
+// Permanent marks a handler error that retrying cannot fix. The bus
+// dead-letters the event without further attempts.
+func Permanent(err error) error {
+	return permanentError{err: err}
+}
+
+type permanentError struct {
+	err error
+}
+
+func (permanent permanentError) Error() string {
+	return permanent.err.Error()
+}
+
+func (permanent permanentError) Unwrap() error {
+	return permanent.err
+}
+
+type EventHandlerFunc func(event Event) error
+
+func (handle EventHandlerFunc) Handle(event Event) error {
+	return handle(event)
+}
+
+// NotifiableHandler puts a Notifiable behind the bus. Its deliveries never fail.
+func NotifiableHandler(notifiable Notifiable) EventHandler {
+	return EventHandlerFunc(func(event Event) error {
+		notifiable.Notify(event)
+		return nil
+	})
+}
+
+type SubscriberOptions struct {
+	Name           string
+	BufferSize     int
+	Overflow       OverflowPolicy
+	SpillDirectory string
+	MaxAttempts    int
+	InitialBackoff time.Duration
+	MaxBackoff     time.Duration
+}
+
+func (options SubscriberOptions) withDefaults() SubscriberOptions {
+	if options.BufferSize <= 0 {
+		options.BufferSize = 64
+	}
+	if options.Overflow == "" {
+		options.Overflow = DropOldest
+	}
+	if options.MaxAttempts <= 0 {
+		options.MaxAttempts = 1
+	}
+	if options.InitialBackoff <= 0 {
+		options.InitialBackoff = 100 * time.Millisecond
+	}
+	if options.MaxBackoff < options.InitialBackoff {
+		options.MaxBackoff = options.InitialBackoff
+	}
+	return options
+}
+
+type DeadLetter struct {
+	Subscriber string
+	Event      Event
+	Err        error
+	Attempts   int
+	At         time.Time
+}
+
+// EventBus hands events to each subscriber through its own buffered queue and
+// worker, so a slow or failing subscriber never holds up parking. Subscribe a
+// lot to the bus to make its deliveries asynchronous.
+type EventBus struct {
+	mutex       sync.Mutex
+	subscribers []*BusSubscriber
+	deadLetters []DeadLetter
+	closed      bool
+	workers     sync.WaitGroup
+}
+
+func EventBusConstruct() *EventBus {
+	return &EventBus{
+		subscribers: []*BusSubscriber{},
+		deadLetters: []DeadLetter{},
+	}
+}
+
+func (bus *EventBus) Subscribe(handler EventHandler, options SubscriberOptions) (*BusSubscriber, error) {
+	bus.mutex.Lock()
+	defer bus.mutex.Unlock()
+	if bus.closed {
+		return nil, Exceptions.ErrEventBusClosed
+	}
+	subscriber, err := newBusSubscriber(bus, handler, options.withDefaults())
+	if err != nil {
+		return nil, err
+	}
+	bus.subscribers = append(bus.subscribers, subscriber)
+	bus.workers.Add(1)
+	go subscriber.run()
+	return subscriber, nil
+}
+
+// Publish queues the event for every subscriber. Only subscribers with the
+// Block policy can make it wait, and only while their queue is full.
+func (bus *EventBus) Publish(event Event) error {
+	bus.mutex.Lock()
+	if bus.closed {
+		bus.mutex.Unlock()
+		return Exceptions.ErrEventBusClosed
+	}
+	subscribers := append([]*BusSubscriber{}, bus.subscribers...)
+	bus.mutex.Unlock()
+	for _, subscriber := range subscribers {
+		subscriber.enqueue(event)
+	}
+	return nil
+}
+
+func (bus *EventBus) Notify(event Event) {
+	bus.Publish(event)
+}
+
+func (bus *EventBus) DeadLetters() []DeadLetter {
+	bus.mutex.Lock()
+	defer bus.mutex.Unlock()
+	return append([]DeadLetter{}, bus.deadLetters...)
+}
+
+func (bus *EventBus) deadLetter(deadLetter DeadLetter) {
+	bus.mutex.Lock()
+	defer bus.mutex.Unlock()
+	bus.deadLetters = append(bus.deadLetters, deadLetter)
+}
+
+// Shutdown stops accepting events and waits for every subscriber to drain
+// what it already has queued, or for the context to end.
+func (bus *EventBus) Shutdown(ctx context.Context) error {
+	bus.mutex.Lock()
+	bus.closed = true
+	subscribers := append([]*BusSubscriber{}, bus.subscribers...)
+	bus.mutex.Unlock()
+	for _, subscriber := range subscribers {
+		subscriber.close()
+	}
+	drained := make(chan struct{})
+	go func() {
+		bus.workers.Wait()
+		close(drained)
+	}()
+	select {
+	case <-drained:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,48 @@
+package Implementations
+
+import "time"
+
+var DefaultRetrievalBuckets = []time.Duration{
+	time.Minute,
+	2 * time.Minute,
+	5 * time.Minute,
+	10 * time.Minute,
+	15 * time.Minute,
+	30 * time.Minute,
+}
+
+// Histogram counts durations into buckets. Counts[i] holds durations up to
+// Bounds[i]; the final count holds everything above the last bound.
+type Histogram struct {
+	Bounds []time.Duration
+	Counts []int
+	Total  int
+	Sum    time.Duration
+}
+
+func HistogramConstruct(bounds []time.Duration) *Histogram {
+	return &Histogram{
+		Bounds: append([]time.Duration{}, bounds...),
+		Counts: make([]int, len(bounds)+1),
+	}
+}
+
+func (histogram *Histogram) Observe(duration time.Duration) {
+	index := len(histogram.Bounds)
+	for i, bound := range histogram.Bounds {
+		if duration <= bound {
+			index = i
+			break
+		}
+	}
+	histogram.Counts[index]++
+	histogram.Total++
+	histogram.Sum += duration
+}
+
+func (histogram *Histogram) Mean() time.Duration {
+	if histogram.Total == 0 {
+		return 0
+	}
+	return histogram.Sum / time.Duration(histogram.Total)
+}
@@ -0,0 +1,20 @@
+package Implementations
+
+import (
+	"ParkingLot_go/Exceptions"
+)
+
+type LeastBusyDispatchStrategy struct{}
+
+func (l *LeastBusyDispatchStrategy) SelectAttendent(availableAttendents []*Attendent, car *Car) (*Attendent, error) {
+	var selectedAttendent *Attendent
+	for _, attendent := range availableAttendents {
+		if selectedAttendent == nil || len(attendent.ParkedCars) < len(selectedAttendent.ParkedCars) {
+			selectedAttendent = attendent
+		}
+	}
+	if selectedAttendent == nil {
+		return nil, Exceptions.ErrNoAttendentAvailable.WithRegistration(car.RegistrationNumber)
+	}
+	return selectedAttendent, nil
+}
@@ -0,0 +1,122 @@
+package Implementations
+
+import (
+	"ParkingLot_go/Enums"
+	"ParkingLot_go/Exceptions"
+)
+
+func (parkinglot *ParkingLot) addressOf(slot *Slot) SlotAddress {
+	for index, candidate := range parkinglot.slots {
+		if candidate == slot {
+			return SlotAddress{ParkingLotId: parkinglot.ParkingLotId, SlotNumber: index + 1}
+		}
+	}
+	return SlotAddress{ParkingLotId: parkinglot.ParkingLotId}
+}
+
+func (parkinglot *ParkingLot) lotEvent(eventType EventType) Event {
+	return Event{Type: eventType, At: parkinglot.now(), ParkingLotId: parkinglot.ParkingLotId, ParkingLotName: parkinglot.Name}
+}
+
+// slotEvent describes the slot as it is now, so it must be taken before the
+// slot changes.
+func (parkinglot *ParkingLot) slotEvent(eventType EventType, slot *Slot, actor *Principal) Event {
+	event := parkinglot.lotEvent(eventType)
+	event.Slot = parkinglot.addressOf(slot)
+	event.Actor = actor.displayName()
+	if !slot.IsFree() {
+		vehicle := *slot.car
+		event.Vehicle = &vehicle
+		event.TicketId = slot.ticket.GetTicketId()
+	}
+	return event
+}
+
+func (parkinglot *ParkingLot) lotChange(changeType ChangeType) StateChange {
+	return StateChange{
+		Type:           changeType,
+		At:             parkinglot.now(),
+		OwnerId:        parkinglot.Owner.identity(),
+		ParkingLotId:   parkinglot.ParkingLotId,
+		ParkingLotName: parkinglot.Name,
+	}
+}
+
+// slotChange, like slotEvent, must be taken before the slot changes.
+func (parkinglot *ParkingLot) slotChange(changeType ChangeType, slot *Slot) StateChange {
+	change := parkinglot.lotChange(changeType)
+	change.SlotNumber = parkinglot.addressOf(slot).SlotNumber
+	change.SlotType = slot.Type()
+	if !slot.IsFree() {
+		change.Vehicle = *slot.car
+		change.TicketId = slot.ticket.GetTicketId()
+		if changeType == ChangeCarParked {
+			change.At = slot.parkedAt
+		}
+	}
+	return change
+}
+
+func (parkinglot *ParkingLot) slotAt(slotNumber int) (*Slot, error) {
+	if slotNumber < 1 || slotNumber > len(parkinglot.slots) {
+		return nil, Exceptions.ErrSlotNotFound.WithLot(parkinglot.ParkingLotId)
+	}
+	return parkinglot.slots[slotNumber-1], nil
+}
+
+// addSlot builds a new slot at the end of the lot and returns its number.
+func (parkinglot *ParkingLot) addSlot(slotType Enums.SlotType) int {
+	slot := SlotConstruct()
+	slot.slotType = slotType
+	parkinglot.slots = append(parkinglot.slots, slot)
+	parkinglot.totalSlots++
+	parkinglot.stateLog.record(parkinglot.slotChange(ChangeSlotAdded, slot))
+	parkinglot.publishOccupancy()
+	return len(parkinglot.slots)
+}
+
+func (parkinglot *ParkingLot) setSlotInService(slotNumber int, inService bool, actor *Principal) error {
+	slot, err := parkinglot.slotAt(slotNumber)
+	if err != nil {
+		return err
+	}
+	if slot.IsInService() == inService {
+		return nil
+	}
+	if !inService && !slot.IsFree() {
+		return Exceptions.ErrSlotIsOccupied.WithLot(parkinglot.ParkingLotId).WithSlot(parkinglot.addressOf(slot))
+	}
+	slot.outOfService = !inService
+	eventType, changeType := SlotOutOfService, ChangeSlotOutOfService
+	if inService {
+		eventType, changeType = SlotInService, ChangeSlotInService
+	}
+	parkinglot.stateLog.record(parkinglot.slotChange(changeType, slot))
+	parkinglot.publish(parkinglot.slotEvent(eventType, slot, actor))
+	parkinglot.publishOccupancy()
+	return nil
+}
+
+// voidTicket cancels a ticket and releases the car it was issued for, for
+// example when a driver has lost the ticket and leaves after proving who
+// they are.
+func (parkinglot *ParkingLot) voidTicket(ticket *Ticket, actor *Principal, tx *parkingTransaction) (*Car, error) {
+	for _, slot := range parkinglot.slots {
+		if !slot.HoldsTicket(ticket) {
+			continue
+		}
+		event := parkinglot.slotEvent(TicketVoided, slot, actor)
+		change := parkinglot.slotChange(ChangeTicketVoided, slot)
+		car, heldTicket, parkedAt := slot.car, slot.ticket, slot.parkedAt
+		slot.release()
+		tx.onRollback(func() { slot.occupy(*car, heldTicket, parkedAt) })
+		tx.afterCommit(func() {
+			parkinglot.stateLog.record(change)
+			parkinglot.publish(event)
+			parkinglot.publishOccupancy()
+			parkinglot.trackDeparture(event)
+		})
+		return car, nil
+	}
+	return nil, Exceptions.ErrInvalidTicket.WithLot(parkinglot.ParkingLotId).WithTicket(ticketIdOf(ticket))
+}
@@ -0,0 +1,316 @@
+package Implementations
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"text/template"
+	"time"
+)
+
+type Locale string
+
+const (
+	English Locale = "en"
+	Hindi   Locale = "hi"
+	Spanish Locale = "es"
+)
+
+// Recipient is who a message is rendered for. A nil Location means UTC.
+type Recipient struct {
+	Locale   Locale
+	Location *time.Location
+}
+
+// messageData is what catalog templates can use.
+type messageData struct {
+	Event
+	LotName      string
+	SlotNumber   int
+	Registration string
+	Occupancy    string
+	Threshold    string
+	Stage        string
+	Time         string
+	ParkedAt     string
+}
+
+var defaultMessages = map[Locale]map[EventType]string{
+	English: {
+		LotFull:                 "{{.LotName}} is full ({{.Occupancy}}) at {{.Time}}.",
+		LotAvailable:            "{{.LotName}} has available slots ({{.Occupancy}}) at {{.Time}}.",
+		CarParked:               "Car {{.Registration}} parked at {{.LotName}}, slot {{.SlotNumber}} at {{.Time}}.",
+		CarUnparked:             "Car {{.Registration}} left {{.LotName}}, slot {{.SlotNumber}} at {{.Time}}.",
+		SlotOutOfService:        "{{.LotName}}, slot {{.SlotNumber}} is out of service since {{.Time}}.",
+		SlotInService:           "{{.LotName}}, slot {{.SlotNumber}} is back in service since {{.Time}}.",
+		TicketVoided:            "Ticket {{.TicketId}} at {{.LotName}}, slot {{.SlotNumber}} was voided at {{.Time}}.",
+		OccupancyAboveThreshold: "{{.LotName}} is {{.Occupancy}} full, at or above the {{.Threshold}} threshold, at {{.Time}}.",
+		OccupancyBelowThreshold: "{{.LotName}} is {{.Occupancy}} full, back below the {{.Threshold}} threshold, at {{.Time}}.",
+		WatchlistMatched:        "Watched vehicle {{.Registration}} parked at {{.LotName}}, slot {{.SlotNumber}} at {{.Time}}.",
+		VehicleOverstayed:       "Car {{.Registration}} at {{.LotName}}, slot {{.SlotNumber}} has been parked since {{.ParkedAt}}: {{.Stage}}.",
+		EmergencyDeclared:       "Emergency at {{.LotName}} at {{.Time}}: {{.Reason}}. The lot is closed to new cars.",
+		VehicleEvacuated:        "Car {{.Registration}} left {{.LotName}}, slot {{.SlotNumber}} during the emergency at {{.Time}}.",
+		LotEvacuated:            "{{.LotName}} has been evacuated at {{.Time}}.",
+		EmergencyLifted:         "The emergency at {{.LotName}} is over at {{.Time}}; the lot is open again.",
+		AttendentRevoked:        "Attendent {{.AttendentId}} was taken off {{.LotName}} at {{.Time}}; {{len .TicketIds}} cars passed to attendent {{.SuccessorId}}.",
+	},
+	Hindi: {
+		LotFull:                 "{{.LotName}} भर गया है ({{.Occupancy}})। समय: {{.Time}}",
+		LotAvailable:            "{{.LotName}} में जगह उपलब्ध है ({{.Occupancy}})। समय: {{.Time}}",
+		CarParked:               "गाड़ी {{.Registration}} {{.LotName}}, स्लॉट {{.SlotNumber}} में पार्क हुई। समय: {{.Time}}",
+		CarUnparked:             "गाड़ी {{.Registration}} {{.LotName}}, स्लॉट {{.SlotNumber}} से निकली। समय: {{.Time}}",
+		SlotOutOfService:        "{{.LotName}}, स्लॉट {{.SlotNumber}} सेवा से बाहर है। समय: {{.Time}}",
+		SlotInService:           "{{.LotName}}, स्लॉट {{.SlotNumber}} फिर से सेवा में है। समय: {{.Time}}",
+		TicketVoided:            "{{.LotName}}, स्लॉट {{.SlotNumber}} का टिकट {{.TicketId}} रद्द किया गया। समय: {{.Time}}",
+		OccupancyAboveThreshold: "{{.LotName}} {{.Occupancy}} भरा है, {{.Threshold}} की सीमा पार हो गई। समय: {{.Time}}",
+		OccupancyBelowThreshold: "{{.LotName}} {{.Occupancy}} भरा है, {{.Threshold}} की सीमा से नीचे। समय: {{.Time}}",
+		WatchlistMatched:        "निगरानी सूची की गाड़ी {{.Registration}} {{.LotName}}, स्लॉट {{.SlotNumber}} में पार्क हुई। समय: {{.Time}}",
+		VehicleOverstayed:       "गाड़ी {{.Registration}} {{.LotName}}, स्लॉट {{.SlotNumber}} में {{.ParkedAt}} से खड़ी है: {{.Stage}}।",
+		EmergencyDeclared:       "{{.LotName}} में आपातकाल: {{.Reason}}। नई गाड़ियाँ नहीं ली जाएँगी। समय: {{.Time}}",
+		VehicleEvacuated:        "आपातकाल के दौरान गाड़ी {{.Registration}} {{.LotName}}, स्लॉट {{.SlotNumber}} से निकली। समय: {{.Time}}",
+		LotEvacuated:            "{{.LotName}} खाली करा लिया गया है। समय: {{.Time}}",
+		EmergencyLifted:         "{{.LotName}} में आपातकाल समाप्त, पार्किंग फिर से खुली है। समय: {{.Time}}",
+		AttendentRevoked:        "अटेंडेंट {{.AttendentId}} को {{.LotName}} से हटाया गया; {{len .TicketIds}} गाड़ियाँ अटेंडेंट {{.SuccessorId}} को सौंपी गईं। समय: {{.Time}}",
+	},
+	Spanish: {
+		LotFull:                 "{{.LotName}} está lleno ({{.Occupancy}}). Hora: {{.Time}}.",
+		LotAvailable:            "{{.LotName}} tiene plazas disponibles ({{.Occupancy}}). Hora: {{.Time}}.",
+		CarParked:               "El vehículo {{.Registration}} se estacionó en {{.LotName}}, plaza {{.SlotNumber}}. Hora: {{.Time}}.",
+		CarUnparked:             "El vehículo {{.Registration}} salió de {{.LotName}}, plaza {{.SlotNumber}}. Hora: {{.Time}}.",
+		SlotOutOfService:        "{{.LotName}}, plaza {{.SlotNumber}} está fuera de servicio. Hora: {{.Time}}.",
+		SlotInService:           "{{.LotName}}, plaza {{.SlotNumber}} vuelve a estar en servicio. Hora: {{.Time}}.",
+		TicketVoided:            "El ticket {{.TicketId}} de {{.LotName}}, plaza {{.SlotNumber}} fue anulado. Hora: {{.Time}}.",
+		OccupancyAboveThreshold: "{{.LotName}} está al {{.Occupancy}}, por encima del umbral de {{.Threshold}}. Hora: {{.Time}}.",
+		OccupancyBelowThreshold: "{{.LotName}} está al {{.Occupancy}}, por debajo del umbral de {{.Threshold}}. Hora: {{.Time}}.",
+		WatchlistMatched:        "El vehículo vigilado {{.Registration}} se estacionó en {{.LotName}}, plaza {{.SlotNumber}}. Hora: {{.Time}}.",
+		VehicleOverstayed:       "El vehículo {{.Registration}} en {{.LotName}}, plaza {{.SlotNumber}} está estacionado desde {{.ParkedAt}}: {{.Stage}}.",
+		EmergencyDeclared:       "Emergencia en {{.LotName}}: {{.Reason}}. No se admiten más vehículos. Hora: {{.Time}}.",
+		VehicleEvacuated:        "El vehículo {{.Registration}} salió de {{.LotName}}, plaza {{.SlotNumber}} durante la emergencia. Hora: {{.Time}}.",
+		LotEvacuated:            "{{.LotName}} ha sido evacuado. Hora: {{.Time}}.",
+		EmergencyLifted:         "Terminó la emergencia en {{.LotName}}; vuelve a estar abierto. Hora: {{.Time}}.",
+		AttendentRevoked:        "El encargado {{.AttendentId}} dejó {{.LotName}}; {{len .TicketIds}} vehículos pasaron al encargado {{.SuccessorId}}. Hora: {{.Time}}.",
+	},
+}
+
+// digestData is what digest templates can use.
+type digestData struct {
+	From string
+	To   string
+	Lots []lotDigestData
+}
+
+type lotDigestData struct {
+	LotName       string
+	TimesFilled   int
+	PeakOccupancy string
+	TimeFull      string
+}
+
+var defaultDigests = map[Locale]string{
+	English: "{{.From}} to {{.To}}{{range .Lots}}; {{.LotName}} filled {{.TimesFilled}} times, peak {{.PeakOccupancy}}, full for {{.TimeFull}}{{end}}",
+	Hindi:   "{{.From}} से {{.To}} तक{{range .Lots}}; {{.LotName}} {{.TimesFilled}} बार भरा, अधिकतम {{.PeakOccupancy}}, {{.TimeFull}} तक भरा रहा{{end}}",
+	Spanish: "Del {{.From}} al {{.To}}{{range .Lots}}; {{.LotName}} se llenó {{.TimesFilled}} veces, máximo {{.PeakOccupancy}}, lleno durante {{.TimeFull}}{{end}}",
+}
+
+var defaultLotNames = map[Locale]string{
+	English: "Parking lot %d",
+	Hindi:   "पार्किंग स्थल %d",
+	Spanish: "Estacionamiento %d",
+}
+
+var defaultStageNames = map[Locale]map[OverstayStage]string{
+	English: {OverstayWarning: "warning", OverstayFinalNotice: "final notice", TowEligible: "eligible for towing"},
+	Hindi:   {OverstayWarning: "चेतावनी", OverstayFinalNotice: "अंतिम सूचना", TowEligible: "टो करने योग्य"},
+	Spanish: {OverstayWarning: "advertencia", OverstayFinalNotice: "aviso final", TowEligible: "apto para remolque"},
+}
+
+const messageTimeLayout = "2006-01-02 15:04 MST"
+
+// MessageCatalog holds a message template per locale and event type, and a
+// digest template per locale. Missing
+// locales and event types fall back to English, as do lot and stage names for
+// a locale added through Register.
+type MessageCatalog struct {
+	mutex      sync.RWMutex
+	templates  map[Locale]map[EventType]*template.Template
+	digests    map[Locale]*template.Template
+	lotNames   map[Locale]string
+	stageNames map[Locale]map[OverstayStage]string
+}
+
+// DefaultCatalog is what notifiers render with unless given their own.
+var DefaultCatalog = MessageCatalogConstruct()
+
+func MessageCatalogConstruct() *MessageCatalog {
+	catalog := &MessageCatalog{
+		templates:  map[Locale]map[EventType]*template.Template{},
+		digests:    map[Locale]*template.Template{},
+		lotNames:   map[Locale]string{},
+		stageNames: map[Locale]map[OverstayStage]string{},
+	}
+	for locale, messages := range defaultMessages {
+		for eventType, text := range messages {
+			if err := catalog.Register(locale, eventType, text); err != nil {
+				panic(err)
+			}
+		}
+		if err := catalog.RegisterDigest(locale, defaultDigests[locale]); err != nil {
+			panic(err)
+		}
+		catalog.lotNames[locale] = defaultLotNames[locale]
+		catalog.stageNames[locale] = defaultStageNames[locale]
+	}
+	return catalog
+}
+
+// Register adds or replaces the template for an event type in a locale.
+func (catalog *MessageCatalog) Register(locale Locale, eventType EventType, text string) error {
+	parsed, err := template.New(string(locale) + "/" + string(eventType)).Option("missingkey=zero").Parse(text)
+	if err != nil {
+		return err
+	}
+	catalog.mutex.Lock()
+	defer catalog.mutex.Unlock()
+	if catalog.templates[locale] == nil {
+		catalog.templates[locale] = map[EventType]*template.Template{}
+	}
+	catalog.templates[locale][eventType] = parsed
+	return nil
+}
+
+// RegisterDigest adds or replaces the digest template in a locale.
+func (catalog *MessageCatalog) RegisterDigest(locale Locale, text string) error {
+	parsed, err := template.New(string(locale) + "/digest").Option("missingkey=zero").Parse(text)
+	if err != nil {
+		return err
+	}
+	catalog.mutex.Lock()
+	defer catalog.mutex.Unlock()
+	if catalog.digests == nil {
+		catalog.digests = map[Locale]*template.Template{}
+	}
+	catalog.digests[locale] = parsed
+	return nil
+}
+
+func (catalog *MessageCatalog) Render(event Event, recipient Recipient) (string, error) {
+	catalog.mutex.RLock()
+	defer catalog.mutex.RUnlock()
+	locale := recipient.Locale
+	if catalog.templates[locale] == nil {
+		locale = English
+	}
+	message, ok := catalog.templates[locale][event.Type]
+	if !ok {
+		message, ok = catalog.templates[English][event.Type]
+	}
+	data := catalog.dataFor(event, recipient, locale)
+	if !ok {
+		return fmt.Sprintf("%s: %s, %s", event.Type, data.LotName, data.Time), nil
+	}
+	var rendered strings.Builder
+	if err := message.Execute(&rendered, data); err != nil {
+		return "", err
+	}
+	return rendered.String(), nil
+}
+
+func (catalog *MessageCatalog) dataFor(event Event, recipient Recipient, locale Locale) messageData {
+	location := recipient.Location
+	if location == nil {
+		location = time.UTC
+	}
+	stage := catalog.stageNames[locale][event.Stage]
+	if stage == "" {
+		stage = catalog.stageNames[English][event.Stage]
+	}
+	if stage == "" {
+		stage = string(event.Stage)
+	}
+	data := messageData{
+		Event:        event,
+		LotName:      catalog.lotName(locale, event.ParkingLotId, event.ParkingLotName),
+		SlotNumber:   event.Slot.SlotNumber,
+		Registration: event.registrationNumber(),
+		Occupancy:    fmt.Sprintf("%.0f%%", event.OccupancyPercent),
+		Threshold:    fmt.Sprintf("%.0f%%", event.Threshold),
+		Stage:        stage,
+		Time:         event.At.In(location).Format(messageTimeLayout),
+	}
+	if !event.ParkedAt.IsZero() {
+		data.ParkedAt = event.ParkedAt.In(location).Format(messageTimeLayout)
+	}
+	return data
+}
+
+func (catalog *MessageCatalog) lotName(locale Locale, parkingLotId int, name string) string {
+	if name != "" {
+		return name
+	}
+	format, ok := catalog.lotNames[locale]
+	if !ok {
+		format = catalog.lotNames[English]
+	}
+	return fmt.Sprintf(format, parkingLotId)
+}
+
+func (catalog *MessageCatalog) RenderDigest(digest Digest, recipient Recipient) (string, error) {
+	catalog.mutex.RLock()
+	defer catalog.mutex.RUnlock()
+	locale := recipient.Locale
+	message, ok := catalog.digests[locale]
+	if !ok {
+		locale = English
+		message = catalog.digests[English]
+	}
+	location := recipient.Location
+	if location == nil {
+		location = time.UTC
+	}
+	data := digestData{
+		From: digest.From.In(location).Format(messageTimeLayout),
+		To:   digest.To.In(location).Format(messageTimeLayout),
+		Lots: make([]lotDigestData, 0, len(digest.Lots)),
+	}
+	for _, lot := range digest.Lots {
+		data.Lots = append(data.Lots, lotDigestData{
+			LotName:       catalog.lotName(locale, lot.ParkingLotId, lot.ParkingLotName),
+			TimesFilled:   lot.TimesFilled,
+			PeakOccupancy: fmt.Sprintf("%.0f%%", lot.PeakOccupancy),
+			TimeFull:      lot.TimeFull.String(),
+		})
+	}
+	if message == nil {
+		return digest.String(), nil
+	}
+	var rendered strings.Builder
+	if err := message.Execute(&rendered, data); err != nil {
+		return "", err
+	}
+	return rendered.String(), nil
+}
+
+// renderMessage never fails; a broken template falls back to the English
+// default so a notification is still sent.
+func renderMessage(catalog *MessageCatalog, event Event, recipient Recipient) string {
+	if catalog == nil {
+		catalog = DefaultCatalog
+	}
+	message, err := catalog.Render(event, recipient)
+	if err != nil {
+		message, _ = DefaultCatalog.Render(event, Recipient{Locale: English, Location: recipient.Location})
+	}
+	return message
+}
+
+// renderDigest never fails, like renderMessage.
+func renderDigest(catalog *MessageCatalog, digest Digest, recipient Recipient) string {
+	if catalog == nil {
+		catalog = DefaultCatalog
+	}
+	message, err := catalog.RenderDigest(digest, recipient)
+	if err != nil {
+		message, _ = DefaultCatalog.RenderDigest(digest, Recipient{Locale: English, Location: recipient.Location})
+	}
+	return message
+}
@@ -0,0 +1,20 @@
+package Implementations
+
+import (
+	"ParkingLot_go/Exceptions"
+)
+
+type NearestEntranceDispatchStrategy struct{}
+
+func (n *NearestEntranceDispatchStrategy) SelectAttendent(availableAttendents []*Attendent, car *Car) (*Attendent, error) {
+	var selectedAttendent *Attendent
+	for _, attendent := range availableAttendents {
+		if selectedAttendent == nil || attendent.DistanceToEntrance < selectedAttendent.DistanceToEntrance {
+			selectedAttendent = attendent
+		}
+	}
+	if selectedAttendent == nil {
+		return nil, Exceptions.ErrNoAttendentAvailable.WithRegistration(car.RegistrationNumber)
+	}
+	return selectedAttendent, nil
+}
@@ -1,7 +1,7 @@
 package Implementations
 
 import (
-	"errors"
+	"ParkingLot_go/Exceptions"
 )
 
 type NormalNextLotStrategy struct{}
@@ -12,5 +12,5 @@ func (n *NormalNextLotStrategy) GetNextLot(assignedParkingLots []*ParkingLot) (*
 			return lot, nil
 		}
 	}
-	return nil, errors.New("all parking lots are full")
+	return nil, Exceptions.ErrAllParkingLotsFull
 }
@@ -1,6 +1,24 @@
 package Implementations
 
+import "reflect"
+
 type Notifiable interface {
-	notifyFull(parkingLotId int)
-	notifyAvailable(parkingLotId int)
+	Notify(event Event)
+}
+
+// NotifiableFunc lets a plain function subscribe to parking lot events.
+type NotifiableFunc func(event Event)
+
+func (notify NotifiableFunc) Notify(event Event) {
+	notify(event)
+}
+
+// sameNotifiable reports whether both are the same subscriber. Subscribers
+// that cannot be compared, such as NotifiableFunc, are never the same.
+func sameNotifiable(first Notifiable, second Notifiable) bool {
+	firstType := reflect.TypeOf(first)
+	if firstType == nil || firstType != reflect.TypeOf(second) || !firstType.Comparable() {
+		return false
+	}
+	return first == second
 }
@@ -0,0 +1,77 @@
+package Implementations
+
+import (
+	"ParkingLot_go/Enums"
+	"time"
+)
+
+// OccupancyRetention is how far back a lot keeps its occupancy series.
+const OccupancyRetention = 24 * time.Hour
+
+type OccupancySample struct {
+	At          time.Time
+	Occupied    int
+	Capacity    int
+	PercentFull float64
+}
+
+type ParkedVehicle struct {
+	Car      Car
+	Slot     SlotAddress
+	SlotType Enums.SlotType
+	TicketId string
+	ParkedAt time.Time
+	Duration time.Duration
+}
+
+func percentOf(part int, whole int) float64 {
+	if whole == 0 {
+		return 0
+	}
+	return float64(part) * 100 / float64(whole)
+}
+
+// recordOccupancy samples the lot after every committed change and drops
+// samples older than OccupancyRetention.
+func (parkinglot *ParkingLot) recordOccupancy() {
+	now := parkinglot.now()
+	occupied := parkinglot.CountParkedCars()
+	parkinglot.occupancy = append(parkinglot.occupancy, OccupancySample{
+		At:          now,
+		Occupied:    occupied,
+		Capacity:    parkinglot.Capacity(),
+		PercentFull: percentOf(occupied, parkinglot.Capacity()),
+	})
+	cutoff := now.Add(-OccupancyRetention)
+	firstKept := 0
+	for firstKept < len(parkinglot.occupancy)-1 && parkinglot.occupancy[firstKept].At.Before(cutoff) {
+		firstKept++
+	}
+	parkinglot.occupancy = parkinglot.occupancy[firstKept:]
+}
+
+func (parkinglot *ParkingLot) OccupancySeries() []OccupancySample {
+	return append([]OccupancySample{}, parkinglot.occupancy...)
+}
+
+func (parkinglot *ParkingLot) PercentFull() float64 {
+	return percentOf(parkinglot.CountParkedCars(), parkinglot.Capacity())
+}
+
+func (parkinglot *ParkingLot) parkedVehicles(now time.Time) []ParkedVehicle {
+	vehicles := []ParkedVehicle{}
+	for index, slot := range parkinglot.slots {
+		if slot.IsFree() {
+			continue
+		}
+		vehicles = append(vehicles, ParkedVehicle{
+			Car:      *slot.car,
+			Slot:     SlotAddress{ParkingLotId: parkinglot.ParkingLotId, SlotNumber: index + 1},
+			SlotType: slot.Type(),
+			TicketId: slot.ticket.GetTicketId(),
+			ParkedAt: slot.parkedAt,
+			Duration: now.Sub(slot.parkedAt),
+		})
+	}
+	return vehicles
+}
@@ -0,0 +1,129 @@
+package Implementations
+
+import (
+	"ParkingLot_go/Exceptions"
+	"time"
+)
+
+// OccupancyThreshold raises an alert once occupancy reaches Percent. The lot
+// has to drop below Percent-Hysteresis before it can alert again, and repeat
+// alerts are held back until MinInterval has passed since the last one; a
+// lot still above Percent then alerts on its next change in occupancy.
+type OccupancyThreshold struct {
+	Percent     float64
+	Hysteresis  float64
+	MinInterval time.Duration
+}
+
+func (threshold OccupancyThreshold) validate() error {
+	if threshold.Percent <= 0 || threshold.Percent > 100 || threshold.Hysteresis < 0 || threshold.Hysteresis >= threshold.Percent || threshold.MinInterval < 0 {
+		return Exceptions.ErrInvalidThreshold
+	}
+	return nil
+}
+
+// thresholdWatch tracks one threshold for either every interested subscriber
+// of the lot or, when subscription is set, a single subscriber.
+type thresholdWatch struct {
+	threshold    OccupancyThreshold
+	subscription *Subscription
+	above        bool
+	alerted      bool
+	lastAlerted  time.Time
+}
+
+func (watch *thresholdWatch) evaluate(percent float64, now time.Time) (EventType, bool) {
+	if !watch.above && percent >= watch.threshold.Percent {
+		if !watch.lastAlerted.IsZero() && now.Sub(watch.lastAlerted) < watch.threshold.MinInterval {
+			return "", false
+		}
+		watch.above = true
+		watch.alerted = true
+		watch.lastAlerted = now
+		return OccupancyAboveThreshold, true
+	}
+	if watch.above && percent < watch.threshold.Percent-watch.threshold.Hysteresis {
+		watch.above = false
+		if !watch.alerted {
+			return "", false
+		}
+		watch.alerted = false
+		return OccupancyBelowThreshold, true
+	}
+	return "", false
+}
+
+func validateThresholds(thresholds []OccupancyThreshold) error {
+	for _, threshold := range thresholds {
+		if err := threshold.validate(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// SetOccupancyThresholds replaces the lot's own thresholds. Their alerts go
+// to every subscriber of OccupancyAboveThreshold or OccupancyBelowThreshold.
+func (parkinglot *ParkingLot) SetOccupancyThresholds(thresholds ...OccupancyThreshold) error {
+	if err := validateThresholds(thresholds); err != nil {
+		return Exceptions.ErrInvalidThreshold.WithLot(parkinglot.ParkingLotId)
+	}
+	watches := []*thresholdWatch{}
+	for _, watch := range parkinglot.thresholds {
+		if watch.subscription != nil {
+			watches = append(watches, watch)
+		}
+	}
+	for _, threshold := range thresholds {
+		watches = append(watches, parkinglot.watch(threshold, nil))
+	}
+	parkinglot.thresholds = watches
+	return nil
+}
+
+// SubscribeThresholds alerts only the given notifiable when the lot crosses
+// any of its own thresholds, until the subscription is unsubscribed.
+func (parkinglot *ParkingLot) SubscribeThresholds(notifiable Notifiable, thresholds ...OccupancyThreshold) (*Subscription, error) {
+	if err := validateThresholds(thresholds); err != nil {
+		return nil, Exceptions.ErrInvalidThreshold.WithLot(parkinglot.ParkingLotId)
+	}
+	subscription := &Subscription{
+		notifiable: notifiable,
+		filter:     EventFilter{EventTypes: []EventType{OccupancyAboveThreshold, OccupancyBelowThreshold}},
+		watching:   parkinglot,
+		active:     true,
+	}
+	for _, threshold := range thresholds {
+		parkinglot.thresholds = append(parkinglot.thresholds, parkinglot.watch(threshold, subscription))
+	}
+	return subscription, nil
+}
+
+// watch starts a threshold from the lot's current occupancy, so a lot that is
+// already above it does not alert until it has dropped back below.
+func (parkinglot *ParkingLot) watch(threshold OccupancyThreshold, subscription *Subscription) *thresholdWatch {
+	return &thresholdWatch{
+		threshold:    threshold,
+		subscription: subscription,
+		above:        parkinglot.PercentFull() >= threshold.Percent,
+	}
+}
+
+func (parkinglot *ParkingLot) publishThresholds() {
+	percent := parkinglot.PercentFull()
+	now := parkinglot.now()
+	for _, watch := range parkinglot.thresholds {
+		eventType, ok := watch.evaluate(percent, now)
+		if !ok {
+			continue
+		}
+		event := parkinglot.lotEvent(eventType)
+		event.Threshold = watch.threshold.Percent
+		event.OccupancyPercent = percent
+		if watch.subscription != nil {
+			watch.subscription.notifiable.Notify(event)
+			continue
+		}
+		parkinglot.publish(event)
+	}
+}
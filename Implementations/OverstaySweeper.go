@@ -0,0 +1,183 @@
+package Implementations
+
+import (
+	"ParkingLot_go/Enums"
+	"sync"
+	"time"
+)
+
+type OverstayStage string
+
+const (
+	OverstayWarning     OverstayStage = "WARNING"
+	OverstayFinalNotice OverstayStage = "FINAL_NOTICE"
+	TowEligible         OverstayStage = "TOW_ELIGIBLE"
+)
+
+var overstayStageOrder = map[OverstayStage]int{
+	"":                  0,
+	OverstayWarning:     1,
+	OverstayFinalNotice: 2,
+	TowEligible:         3,
+}
+
+// OverstayLimits says how long a car may stay in a lot. A car past its limit
+// gets a warning, then a final notice FinalNoticeAfter later, and becomes
+// eligible for towing TowEligibleAfter past the limit. A zero limit means no
+// limit.
+type OverstayLimits struct {
+	Default          time.Duration
+	BySlotType       map[Enums.SlotType]time.Duration
+	FinalNoticeAfter time.Duration
+	TowEligibleAfter time.Duration
+}
+
+func (limits OverstayLimits) limitFor(slotType Enums.SlotType) time.Duration {
+	if limit, ok := limits.BySlotType[slotType]; ok {
+		return limit
+	}
+	return limits.Default
+}
+
+func (limits OverstayLimits) stageFor(vehicle ParkedVehicle) OverstayStage {
+	limit := limits.limitFor(vehicle.SlotType)
+	if limit <= 0 || vehicle.Duration <= limit {
+		return ""
+	}
+	overstay := vehicle.Duration - limit
+	if limits.TowEligibleAfter > 0 && overstay >= limits.TowEligibleAfter {
+		return TowEligible
+	}
+	if limits.FinalNoticeAfter > 0 && overstay >= limits.FinalNoticeAfter {
+		return OverstayFinalNotice
+	}
+	return OverstayWarning
+}
+
+func (parkinglot *ParkingLot) SetOverstayLimits(limits OverstayLimits) {
+	parkinglot.overstayLimits = limits
+}
+
+// OverstaySweeper periodically looks for cars parked past their lot's limits
+// and publishes a VehicleOverstayed event each time a car reaches a later
+// stage. Lots are not safe for concurrent use, so sweeps always run on the
+// caller's goroutine: the one that parks and unparks in the swept lots.
+type OverstaySweeper struct {
+	Clock  Clock
+	mutex  sync.Mutex
+	lots   []*ParkingLot
+	stages map[string]OverstayStage
+	due    chan time.Time
+	stop   chan struct{}
+	done   chan struct{}
+}
+
+func OverstaySweeperConstruct(clock Clock, parkingLots ...*ParkingLot) *OverstaySweeper {
+	if clock == nil {
+		clock = SystemClock{}
+	}
+	return &OverstaySweeper{
+		Clock:  clock,
+		lots:   append([]*ParkingLot{}, parkingLots...),
+		stages: map[string]OverstayStage{},
+		due:    make(chan time.Time, 1),
+	}
+}
+
+func (sweeper *OverstaySweeper) Watch(parkingLot *ParkingLot) {
+	sweeper.mutex.Lock()
+	defer sweeper.mutex.Unlock()
+	if !contains(sweeper.lots, parkingLot) {
+		sweeper.lots = append(sweeper.lots, parkingLot)
+	}
+}
+
+// Sweep checks every watched lot once and returns the events it raised.
+func (sweeper *OverstaySweeper) Sweep() []Event {
+	sweeper.mutex.Lock()
+	defer sweeper.mutex.Unlock()
+	now := sweeper.Clock.Now()
+	raised := []Event{}
+	stillParked := map[string]bool{}
+	for _, parkingLot := range sweeper.lots {
+		for _, vehicle := range parkingLot.parkedVehicles(now) {
+			stillParked[vehicle.TicketId] = true
+			stage := parkingLot.overstayLimits.stageFor(vehicle)
+			if overstayStageOrder[stage] <= overstayStageOrder[sweeper.stages[vehicle.TicketId]] {
+				continue
+			}
+			sweeper.stages[vehicle.TicketId] = stage
+			car := vehicle.Car
+			event := Event{
+				Type:           VehicleOverstayed,
+				At:             now,
+				ParkingLotId:   parkingLot.GetParkingLotId(),
+				ParkingLotName: parkingLot.Name,
+				Slot:           vehicle.Slot,
+				Vehicle:        &car,
+				TicketId:       vehicle.TicketId,
+				Actor:          "overstay-sweeper",
+				Stage:          stage,
+				ParkedAt:       vehicle.ParkedAt,
+			}
+			parkingLot.publish(event)
+			raised = append(raised, event)
+		}
+	}
+	for ticketId := range sweeper.stages {
+		if !stillParked[ticketId] {
+			delete(sweeper.stages, ticketId)
+		}
+	}
+	return raised
+}
+
+// Due receives a tick when a sweep started by Start is due. The receiver runs
+// Sweep itself. Ticks missed while one is pending are dropped.
+func (sweeper *OverstaySweeper) Due() <-chan time.Time {
+	return sweeper.due
+}
+
+// Start ticks Due every interval in the background until Stop is called. The
+// background goroutine never touches the lots; it only says when to sweep:
+//
+//	case <-sweeper.Due():
+//		sweeper.Sweep()
+func (sweeper *OverstaySweeper) Start(interval time.Duration) {
+	sweeper.mutex.Lock()
+	if sweeper.stop != nil {
+		sweeper.mutex.Unlock()
+		return
+	}
+	stop, done := make(chan struct{}), make(chan struct{})
+	sweeper.stop, sweeper.done = stop, done
+	sweeper.mutex.Unlock()
+	go func() {
+		defer close(done)
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case tick := <-ticker.C:
+				select {
+				case sweeper.due <- tick:
+				default:
+				}
+			case <-stop:
+				return
+			}
+		}
+	}()
+}
+
+func (sweeper *OverstaySweeper) Stop() {
+	sweeper.mutex.Lock()
+	stop, done := sweeper.stop, sweeper.done
+	sweeper.stop, sweeper.done = nil, nil
+	sweeper.mutex.Unlock()
+	if stop == nil {
+		return
+	}
+	close(stop)
+	<-done
+}
@@ -1,15 +1,25 @@
 package Implementations
 
 import (
+	"ParkingLot_go/Enums"
 	"ParkingLot_go/Exceptions"
-	"errors"
-	"fmt"
+	"github.com/google/uuid"
+	"time"
 )
 
 type Owner struct {
+	Id               string
 	Attendents       []*Attendent
 	OwnerParkingLots []*ParkingLot
 	notifiables      []Notifiable
+	DispatchStrategy DispatchStrategy
+	Revocations      []*Revocation
+	accessControl    *AccessControl
+	subscriptions    []*Subscription
+	delivery         *DigestNotifier
+	Recipient        Recipient
+	stateLog         *StateLog
+	emergencyReason  *string
 	Attendent
 }
 
@@ -17,18 +27,119 @@ func OwnerConstruct() *Owner {
 	return &Owner{
 		Attendents:       []*Attendent{},
 		OwnerParkingLots: []*ParkingLot{},
+		DispatchStrategy: &LeastBusyDispatchStrategy{},
+		Revocations:      []*Revocation{},
 		Attendent:        *AttendentConstructDefault(),
 	}
 }
 
+// EnableAccessControl puts the owner, its current and future lots under the
+// access control. An owner without a principal gets one of its own with the
+// owner role. The owner's and its attendents' principals are given the lots
+// they already have.
+func (owner *Owner) EnableAccessControl(accessControl *AccessControl) {
+	owner.accessControl = accessControl
+	if owner.Principal == nil {
+		owner.Principal = PrincipalConstruct("owner-"+owner.identity(), RoleOwner)
+	}
+	for _, lot := range owner.OwnerParkingLots {
+		lot.accessControl = accessControl
+		owner.Principal.addLot(lot.GetParkingLotId())
+	}
+	for _, attendent := range owner.Attendents {
+		for _, lot := range attendent.AssignedParkingLots {
+			attendent.Principal.addLot(lot.GetParkingLotId())
+		}
+	}
+}
+
+// EnableStateLog records every later change to the owner, its lots and their
+// attendents in the log. The log starts with everything as it stands now.
+func (owner *Owner) EnableStateLog(log *StateLog) {
+	owner.stateLog = log
+	recordOwner(log, owner)
+	for _, lot := range owner.OwnerParkingLots {
+		lot.stateLog = log
+		recordLot(log, lot)
+	}
+	for _, attendent := range append([]*Attendent{&owner.Attendent}, owner.Attendents...) {
+		if attendent != &owner.Attendent {
+			log.record(owner.attendentChange(ChangeAttendentHired, attendent))
+		}
+		recordAttendent(log, attendent, owner.OwnerParkingLots)
+	}
+}
+
+// StateLog returns the log the owner records its changes in, or nil.
+func (owner *Owner) StateLog() *StateLog {
+	return owner.stateLog
+}
+
+func (owner *Owner) identity() string {
+	if owner.Id == "" {
+		owner.Id = uuid.NewString()
+	}
+	return owner.Id
+}
+
+func (owner *Owner) attendentChange(changeType ChangeType, attendent *Attendent) StateChange {
+	return StateChange{Type: changeType, At: owner.now(), OwnerId: owner.identity(), AttendentId: attendent.identity()}
+}
+
+func (owner *Owner) employ(attendent *Attendent) {
+	owner.Attendents = append(owner.Attendents, attendent)
+	owner.stateLog.record(owner.attendentChange(ChangeAttendentHired, attendent))
+}
+
+func (owner *Owner) authorize(permission Permission, parkingLot *ParkingLot) error {
+	return authorize(owner.accessControl, owner.Principal, permission, parkingLot.GetParkingLotId())
+}
+
+func (owner *Owner) authorizeGlobal(permission Permission) error {
+	return authorizeGlobal(owner.accessControl, owner.Principal, permission)
+}
+
 func (owner *Owner) CreateParkingLot(totalSlots int) *ParkingLot {
-	if totalSlots <= 0 {
-		panic(Exceptions.ErrCannotCreateParkingLotException)
+	parkingLot, err := owner.CreateParkingLotChecked(totalSlots)
+	if err != nil {
+		panic(err)
 	}
-	parkingLot := ParkingLotConstruct(totalSlots, owner)
+	return parkingLot
+}
+
+// CreateParkingLotChecked is CreateParkingLot reporting an invalid size as
+// an error instead of panicking.
+func (owner *Owner) CreateParkingLotChecked(totalSlots int) (*ParkingLot, error) {
+	parkingLot, err := ParkingLotConstructChecked(totalSlots, owner)
+	if err != nil {
+		return nil, err
+	}
+	parkingLot.accessControl = owner.accessControl
+	owner.Principal.addLot(parkingLot.GetParkingLotId())
+	parkingLot.Clock = owner.Clock
+	parkingLot.stateLog = owner.stateLog
+	recordLot(owner.stateLog, parkingLot)
 	parkingLot.RegisterNotifiable(owner)
+	for _, subscription := range owner.subscriptions {
+		subscription.attach(parkingLot)
+	}
 	owner.OwnerParkingLots = append(owner.OwnerParkingLots, parkingLot)
-	return parkingLot
+	if owner.emergencyReason != nil {
+		parkingLot.declareEmergency(*owner.emergencyReason, owner.Principal)
+	}
+	return parkingLot, nil
+}
+
+// CreateParkingLotAs is CreateParkingLotChecked for a principal allowed to
+// create lots.
+func (owner *Owner) CreateParkingLotAs(principal *Principal, totalSlots int) (*ParkingLot, error) {
+	if totalSlots <= 0 {
+		return nil, Exceptions.ErrCannotCreateParkingLotException
+	}
+	if err := authorizeGlobal(owner.accessControl, principal, PermissionCreateLot); err != nil {
+		return nil, err
+	}
+	return owner.CreateParkingLotChecked(totalSlots)
 }
 
 func (owner *Owner) AssignParkingLotToAttendent(attendent *Attendent, parkingLot *ParkingLot) error {
@@ -40,18 +151,370 @@ func (owner *Owner) AssignParkingLotToAttendent(attendent *Attendent, parkingLot
 		}
 	}
 	if !isOwnedByThisOwner {
-		return errors.New("this parking lot is not owned by the owner")
+		return Exceptions.ErrParkingLotNotOwned.WithLot(parkingLot.GetParkingLotId())
+	}
+	if err := owner.authorize(PermissionAssignAttendent, parkingLot); err != nil {
+		return err
+	}
+	if err := attendent.Assign(parkingLot, owner); err != nil {
+		return err
+	}
+	if !owner.employs(attendent) {
+		owner.employ(attendent)
+	}
+	return nil
+}
+
+// HireAttendent registers the attendent with this owner so the dispatch desk
+// can hand it cars.
+func (owner *Owner) HireAttendent(attendent *Attendent) error {
+	if err := owner.authorizeGlobal(PermissionAssignAttendent); err != nil {
+		return err
+	}
+	if owner.employs(attendent) {
+		return Exceptions.ErrAttendentAlreadyHired
+	}
+	if attendent.AssignedOwner != nil && attendent.AssignedOwner != owner {
+		return Exceptions.ErrAttendentEmployedElsewhere
+	}
+	attendent.AssignedOwner = owner
+	owner.employ(attendent)
+	return nil
+}
+
+// ContractAttendent engages an attendent employed elsewhere to work the given
+// lots between start and end, paying feePerPark minor currency units for every
+// car it parks. A lot can be contracted again for a period that does not
+// overlap its earlier contracts.
+func (owner *Owner) ContractAttendent(attendent *Attendent, parkingLots []*ParkingLot, start time.Time, end time.Time, feePerPark int64) (*Contract, error) {
+	if err := owner.authorizeGlobal(PermissionAssignAttendent); err != nil {
+		return nil, err
+	}
+	if !end.After(start) {
+		return nil, Exceptions.ErrInvalidContract
+	}
+	for _, lot := range parkingLots {
+		if !contains(owner.OwnerParkingLots, lot) {
+			return nil, Exceptions.ErrParkingLotNotOwned.WithLot(lot.GetParkingLotId())
+		}
+		contracted := false
+		for _, existing := range attendent.Contracts {
+			if existing.Owner != owner || !existing.covers(lot) {
+				continue
+			}
+			if existing.overlaps(start, end) {
+				return nil, Exceptions.ErrOverlappingContract.WithLot(lot.GetParkingLotId())
+			}
+			contracted = true
+		}
+		if !contracted && contains(attendent.AssignedParkingLots, lot) {
+			return nil, Exceptions.ErrParkingLotAlreadyAssigned.WithLot(lot.GetParkingLotId())
+		}
+	}
+	contract := &Contract{
+		Owner:       owner,
+		ParkingLots: append([]*ParkingLot{}, parkingLots...),
+		Start:       start,
+		End:         end,
+		FeePerPark:  feePerPark,
+	}
+	attendent.Contracts = append(attendent.Contracts, contract)
+	tx := newParkingTransaction()
+	for _, lot := range parkingLots {
+		if !contains(attendent.AssignedParkingLots, lot) {
+			attendent.addLot(lot, tx)
+		}
+	}
+	tx.commit()
+	if !owner.employs(attendent) {
+		owner.employ(attendent)
+	}
+	return contract, nil
+}
+
+func (owner *Owner) employs(attendent *Attendent) bool {
+	for _, hired := range owner.Attendents {
+		if hired == attendent {
+			return true
+		}
+	}
+	return false
+}
+
+// DispatchPark picks an available attendent using the owner's dispatch
+// strategy and has it park the car.
+func (owner *Owner) DispatchPark(car *Car) (*Ticket, error) {
+	strategy := owner.DispatchStrategy
+	if strategy == nil {
+		strategy = &LeastBusyDispatchStrategy{}
+	}
+	isAvailable := func(attendent *Attendent) bool { return attendent.isAvailableFor(owner) }
+	var attendent *Attendent
+	var err error
+	if rosterStrategy, ok := strategy.(rosterDispatchStrategy); ok {
+		attendent, err = rosterStrategy.selectFromRoster(owner.Attendents, isAvailable, car)
+	} else {
+		availableAttendents := []*Attendent{}
+		for _, candidate := range owner.Attendents {
+			if isAvailable(candidate) {
+				availableAttendents = append(availableAttendents, candidate)
+			}
+		}
+		attendent, err = strategy.SelectAttendent(availableAttendents, car)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return attendent.ParkFor(owner, car)
+}
+
+// DispatchUnpark routes the ticket to the attendent holding the car. A car
+// parked straight at the lot goes to an attendent serving that lot, or is
+// unparked by the owner when nobody does.
+func (owner *Owner) DispatchUnpark(ticket *Ticket) (*Car, error) {
+	for _, attendent := range owner.Attendents {
+		if attendent.HoldsTicket(ticket) {
+			return attendent.Unpark(ticket)
+		}
+	}
+	if owner.Attendent.HoldsTicket(ticket) {
+		return owner.Unpark(ticket)
+	}
+	for _, attendent := range append(append([]*Attendent{}, owner.Attendents...), &owner.Attendent) {
+		if attendent.locate(ticket) != nil {
+			return attendent.Unpark(ticket)
+		}
+	}
+	for _, lot := range owner.OwnerParkingLots {
+		if _, ok := lot.carFor(ticket); ok {
+			return lot.UnparkAs(owner.Principal, ticket)
+		}
+	}
+	return nil, Exceptions.ErrCarNotFound.WithTicket(ticketIdOf(ticket))
+}
+
+// RevokeParkingLotFromAttendent takes the lot away from the attendent. The
+// cars it parked there move to another of the owner's attendents serving the
+// lot, or to the owner when nobody else does.
+func (owner *Owner) RevokeParkingLotFromAttendent(attendent *Attendent, parkingLot *ParkingLot) (*Revocation, error) {
+	if !contains(owner.OwnerParkingLots, parkingLot) {
+		return nil, Exceptions.ErrParkingLotNotOwned.WithLot(parkingLot.GetParkingLotId())
+	}
+	if !contains(attendent.AssignedParkingLots, parkingLot) {
+		return nil, Exceptions.ErrParkingLotNotAssigned.WithLot(parkingLot.GetParkingLotId())
+	}
+	if err := owner.authorize(PermissionAssignAttendent, parkingLot); err != nil {
+		return nil, err
+	}
+	var successor *Attendent
+	for _, candidate := range owner.Attendents {
+		if candidate != attendent && contains(candidate.AssignedParkingLots, parkingLot) {
+			successor = candidate
+			break
+		}
+	}
+	revocation := &Revocation{
+		Kind:          LotRevoked,
+		Attendent:     attendent,
+		ParkingLotIds: []int{parkingLot.GetParkingLotId()},
+		TransferredTo: successor,
+		At:            owner.now(),
 	}
-	return attendent.Assign(parkingLot, owner)
+	tx := newParkingTransaction()
+	if err := owner.transferLot(attendent, parkingLot, successor, revocation, tx); err != nil {
+		tx.rollback()
+		return nil, err
+	}
+	tx.commit()
+	owner.recordRevocation(revocation)
+	return revocation, nil
+}
+
+// FireAttendent lets the attendent go. Its lots of this owner and the cars in
+// them move to the successor, or to the owner when successor is nil. Either
+// every lot moves or, if one cannot, none do and the attendent stays on.
+func (owner *Owner) FireAttendent(attendent *Attendent, successor *Attendent) (*Revocation, error) {
+	if !owner.employs(attendent) {
+		return nil, Exceptions.ErrAttendentNotEmployed
+	}
+	if err := owner.authorizeGlobal(PermissionAssignAttendent); err != nil {
+		return nil, err
+	}
+	if successor != nil && (successor == attendent || !owner.employs(successor)) {
+		return nil, Exceptions.ErrAttendentNotEmployed
+	}
+	revocation := &Revocation{
+		Kind:          AttendentFired,
+		Attendent:     attendent,
+		ParkingLotIds: []int{},
+		TransferredTo: successor,
+		At:            owner.now(),
+	}
+	tx := newParkingTransaction()
+	for _, lot := range append([]*ParkingLot{}, attendent.AssignedParkingLots...) {
+		if !contains(owner.OwnerParkingLots, lot) {
+			continue
+		}
+		revocation.ParkingLotIds = append(revocation.ParkingLotIds, lot.GetParkingLotId())
+		if err := owner.transferLot(attendent, lot, successor, revocation, tx); err != nil {
+			tx.rollback()
+			return nil, err
+		}
+	}
+	tx.commit()
+	for i, hired := range owner.Attendents {
+		if hired == attendent {
+			owner.Attendents = append(owner.Attendents[:i], owner.Attendents[i+1:]...)
+			owner.stateLog.record(owner.attendentChange(ChangeAttendentFired, attendent))
+			break
+		}
+	}
+	if attendent.AssignedOwner == owner {
+		attendent.AssignedOwner = nil
+	}
+	attendent.endContractsWith(owner)
+	owner.recordRevocation(revocation)
+	return revocation, nil
+}
+
+// transferLot moves the lot and the attendent's cars in it to the successor,
+// or to the owner when successor is nil, and announces the revocation once
+// the transaction commits.
+func (owner *Owner) transferLot(attendent *Attendent, parkingLot *ParkingLot, successor *Attendent, revocation *Revocation, tx *parkingTransaction) error {
+	receiver := successor
+	if receiver == nil {
+		receiver = &owner.Attendent
+	}
+	if !contains(receiver.AssignedParkingLots, parkingLot) {
+		if err := receiver.assign(parkingLot, owner, tx); err != nil {
+			return err
+		}
+	}
+	ticketIds := receiver.takeOverLot(attendent, parkingLot, tx)
+	revocation.TicketIds = append(revocation.TicketIds, ticketIds...)
+	event := owner.revocationEvent(revocation, parkingLot, receiver, ticketIds)
+	tx.afterCommit(func() { parkingLot.publish(event) })
+	return nil
+}
+
+func (owner *Owner) revocationEvent(revocation *Revocation, parkingLot *ParkingLot, receiver *Attendent, ticketIds []string) Event {
+	event := parkingLot.lotEvent(AttendentRevoked)
+	event.Actor = owner.Principal.displayName()
+	event.Reason = string(revocation.Kind)
+	event.AttendentId = revocation.Attendent.identity()
+	event.SuccessorId = receiver.identity()
+	event.TicketIds = ticketIds
+	return event
+}
+
+// TransferParkingLot sells the lot to another owner. Parked cars and tickets
+// stay valid; the old owner's attendents on the lot are revoked or
+// recontracted according to the terms, and the lot records the transfer.
+func (owner *Owner) TransferParkingLot(parkingLot *ParkingLot, newOwner *Owner, terms TransferTerms) (*OwnershipTransfer, error) {
+	if !contains(owner.OwnerParkingLots, parkingLot) {
+		return nil, Exceptions.ErrParkingLotNotOwned.WithLot(parkingLot.GetParkingLotId())
+	}
+	if newOwner == nil || newOwner == owner {
+		return nil, Exceptions.ErrInvalidTransfer.WithLot(parkingLot.GetParkingLotId())
+	}
+	if err := owner.authorize(PermissionAssignAttendent, parkingLot); err != nil {
+		return nil, err
+	}
+	now := owner.now()
+	if terms.Policy == RecontractAttendents && !terms.ContractUntil.After(now) {
+		return nil, Exceptions.ErrInvalidContract.WithLot(parkingLot.GetParkingLotId())
+	}
+	transfer := &OwnershipTransfer{
+		ParkingLotId:           parkingLot.GetParkingLotId(),
+		From:                   owner,
+		To:                     newOwner,
+		At:                     now,
+		Policy:                 terms.Policy,
+		RevokedAttendents:      []*Attendent{},
+		RecontractedAttendents: []*Attendent{},
+		ActiveTicketIds:        parkingLot.activeTicketIds(),
+	}
+
+	tx := newParkingTransaction()
+	for _, attendent := range append([]*Attendent{}, owner.Attendents...) {
+		if !contains(attendent.AssignedParkingLots, parkingLot) {
+			continue
+		}
+		if terms.Policy == RecontractAttendents {
+			attendent.recontract(parkingLot, &Contract{
+				Owner:       newOwner,
+				ParkingLots: []*ParkingLot{parkingLot},
+				Start:       now,
+				End:         terms.ContractUntil,
+				FeePerPark:  terms.FeePerPark,
+			}, tx)
+			if !newOwner.employs(attendent) {
+				newOwner.employ(attendent)
+			}
+			transfer.RecontractedAttendents = append(transfer.RecontractedAttendents, attendent)
+			continue
+		}
+		revocation := &Revocation{
+			Kind:          LotRevoked,
+			Attendent:     attendent,
+			ParkingLotIds: []int{parkingLot.GetParkingLotId()},
+			TransferredTo: &newOwner.Attendent,
+			At:            now,
+		}
+		revocation.TicketIds = newOwner.Attendent.takeOverLot(attendent, parkingLot, tx)
+		event := owner.revocationEvent(revocation, parkingLot, &newOwner.Attendent, revocation.TicketIds)
+		tx.afterCommit(func() {
+			owner.recordRevocation(revocation)
+			parkingLot.publish(event)
+		})
+		transfer.RevokedAttendents = append(transfer.RevokedAttendents, attendent)
+	}
+	if contains(owner.Attendent.AssignedParkingLots, parkingLot) {
+		newOwner.Attendent.takeOverLot(&owner.Attendent, parkingLot, tx)
+	}
+	tx.commit()
+
+	for i, lot := range owner.OwnerParkingLots {
+		if lot == parkingLot {
+			owner.OwnerParkingLots = append(owner.OwnerParkingLots[:i], owner.OwnerParkingLots[i+1:]...)
+			break
+		}
+	}
+	newOwner.OwnerParkingLots = append(newOwner.OwnerParkingLots, parkingLot)
+	parkingLot.changeOwner(transfer)
+	return transfer, nil
+}
+
+// AttendentPerformanceReport compares the owner's attendents side by side
+// over the window from..to.
+func (owner *Owner) AttendentPerformanceReport(from time.Time, to time.Time) ([]AttendentPerformance, error) {
+	if err := owner.authorizeGlobal(PermissionViewReports); err != nil {
+		return nil, err
+	}
+	report := []AttendentPerformance{}
+	for _, attendent := range owner.Attendents {
+		report = append(report, AttendentPerformance{
+			Attendent: attendent,
+			Summary:   attendent.Metrics().Summary(from, to),
+		})
+	}
+	return report, nil
+}
+
+func (owner *Owner) recordRevocation(revocation *Revocation) {
+	owner.Revocations = append(owner.Revocations, revocation)
 }
 
 func (owner *Owner) AssignParkingLotToSelf(parkingLot *ParkingLot) error {
 	for _, ownerLot := range owner.OwnerParkingLots {
 		if ownerLot == parkingLot {
+			if err := owner.authorize(PermissionAssignAttendent, parkingLot); err != nil {
+				return err
+			}
 			return owner.Assign(parkingLot, owner)
 		}
 	}
-	return errors.New("this parking lot is not owned by this owner")
+	return Exceptions.ErrParkingLotNotOwned.WithLot(parkingLot.GetParkingLotId())
 }
 
 func contains(lots []*ParkingLot, lot *ParkingLot) bool {
@@ -63,14 +526,85 @@ func contains(lots []*ParkingLot, lot *ParkingLot) bool {
 	return false
 }
 
-func (owner *Owner) RegisterNotifiable(parkingLot *ParkingLot, notifiable Notifiable) {
-	parkingLot.RegisterNotifiable(notifiable)
+func (owner *Owner) RegisterNotifiable(parkingLot *ParkingLot, notifiable Notifiable) *Subscription {
+	return parkingLot.RegisterNotifiable(notifiable)
+}
+
+func (owner *Owner) Notify(event Event) {
+	if owner.delivery != nil {
+		owner.delivery.Notify(event)
+		return
+	}
+	console{name: "Owner", recipient: &owner.Recipient}.Notify(event)
+}
+
+// SetDeliveryPreferences changes how the owner's own notifications arrive,
+// for example as an hourly digest with quiet hours overnight.
+func (owner *Owner) SetDeliveryPreferences(preferences DeliveryPreferences) *DigestNotifier {
+	owner.delivery = DigestNotifierConstruct(console{name: "Owner", recipient: &owner.Recipient}, preferences, owner.Clock)
+	return owner.delivery
+}
+
+func (owner *Owner) manage(parkingLot *ParkingLot) error {
+	if !contains(owner.OwnerParkingLots, parkingLot) {
+		return Exceptions.ErrParkingLotNotOwned.WithLot(parkingLot.GetParkingLotId())
+	}
+	return owner.authorize(PermissionManageLot, parkingLot)
+}
+
+func (owner *Owner) SetSlotType(parkingLot *ParkingLot, slotNumber int, slotType Enums.SlotType) error {
+	if err := owner.manage(parkingLot); err != nil {
+		return err
+	}
+	slot, err := parkingLot.slotAt(slotNumber)
+	if err != nil {
+		return err
+	}
+	slot.slotType = slotType
+	parkingLot.stateLog.record(parkingLot.slotChange(ChangeSlotTypeSet, slot))
+	return nil
 }
 
-func (owner *Owner) notifyFull(parkingLotId int) {
-	fmt.Printf("Owner notified: Parking lot with ID %d is full.\n", parkingLotId)
+// AddSlot extends the lot with a new slot of the given type and returns its
+// slot number.
+func (owner *Owner) AddSlot(parkingLot *ParkingLot, slotType Enums.SlotType) (int, error) {
+	if err := owner.manage(parkingLot); err != nil {
+		return 0, err
+	}
+	return parkingLot.addSlot(slotType), nil
 }
 
-func (owner *Owner) notifyAvailable(parkingLotId int) {
-	fmt.Printf("Owner notified: Parking lot with ID %d has available slots.\n", parkingLotId)
+func (owner *Owner) TakeSlotOutOfService(parkingLot *ParkingLot, slotNumber int) error {
+	if err := owner.manage(parkingLot); err != nil {
+		return err
+	}
+	return parkingLot.setSlotInService(slotNumber, false, owner.Principal)
+}
+
+func (owner *Owner) ReturnSlotToService(parkingLot *ParkingLot, slotNumber int) error {
+	if err := owner.manage(parkingLot); err != nil {
+		return err
+	}
+	return parkingLot.setSlotInService(slotNumber, true, owner.Principal)
+}
+
+// VoidTicket cancels a ticket and releases its car from the lot, dropping the
+// ticket from whichever attendent was holding it.
+func (owner *Owner) VoidTicket(parkingLot *ParkingLot, ticket *Ticket) (*Car, error) {
+	if err := owner.manage(parkingLot); err != nil {
+		return nil, err
+	}
+	tx := newParkingTransaction()
+	car, err := parkingLot.voidTicket(ticket, owner.Principal, tx)
+	if err != nil {
+		tx.rollback()
+		return nil, err
+	}
+	for _, attendent := range append([]*Attendent{&owner.Attendent}, owner.Attendents...) {
+		if attended, ok := attendent.tickets[ticket.GetTicketId()]; ok {
+			attendent.untrack(attended, tx)
+		}
+	}
+	tx.commit()
+	return car, nil
 }
@@ -0,0 +1,30 @@
+package Implementations
+
+import "time"
+
+type AttendentTransferPolicy string
+
+const (
+	RevokeAttendents     AttendentTransferPolicy = "REVOKE"
+	RecontractAttendents AttendentTransferPolicy = "RECONTRACT"
+)
+
+// TransferTerms decide what happens to the old owner's attendents working the
+// lot. Recontracted attendents keep their cars and work the lot for the new
+// owner until ContractUntil.
+type TransferTerms struct {
+	Policy        AttendentTransferPolicy
+	ContractUntil time.Time
+	FeePerPark    int64
+}
+
+type OwnershipTransfer struct {
+	ParkingLotId           int
+	From                   *Owner
+	To                     *Owner
+	At                     time.Time
+	Policy                 AttendentTransferPolicy
+	RevokedAttendents      []*Attendent
+	RecontractedAttendents []*Attendent
+	ActiveTicketIds        []string
+}
@@ -0,0 +1,52 @@
+package Implementations
+
+import "time"
+
+type CandidateOutcome string
+
+const (
+	CandidateSelected     CandidateOutcome = "SELECTED"
+	CandidateFull         CandidateOutcome = "FULL"
+	CandidateIncompatible CandidateOutcome = "INCOMPATIBLE"
+	CandidateFiltered     CandidateOutcome = "FILTERED"
+)
+
+type LotCandidate struct {
+	ParkingLotId int
+	Outcome      CandidateOutcome
+	Reason       string
+}
+
+// ParkingDecision explains how an attendent chose the lot and slot for a car.
+type ParkingDecision struct {
+	TicketId             string
+	Car                  Car
+	Strategy             string
+	Candidates           []LotCandidate
+	SelectedParkingLotId int
+	Slot                 SlotAddress
+	SlotReason           string
+	DecidedAt            time.Time
+}
+
+func (decision *ParkingDecision) Candidate(parkingLotId int) (LotCandidate, bool) {
+	for _, candidate := range decision.Candidates {
+		if candidate.ParkingLotId == parkingLotId {
+			return candidate, true
+		}
+	}
+	return LotCandidate{}, false
+}
+
+func (decision *ParkingDecision) rejectSelected(lot *ParkingLot, err error) {
+	for index, candidate := range decision.Candidates {
+		if candidate.ParkingLotId == lot.GetParkingLotId() {
+			decision.Candidates[index].Outcome = CandidateIncompatible
+			decision.Candidates[index].Reason = err.Error()
+		}
+	}
+}
+
+func strategyName(strategy NextLotStrategy) string {
+	return typeName(strategy)
+}
@@ -5,36 +5,56 @@ import (
 	"ParkingLot_go/Exceptions"
 	"github.com/google/uuid"
 	"math/big"
+	"time"
 )
 
 type ParkingLot struct {
-	totalSlots   int
-	slots        []*Slot
-	ParkingLotId int
-	notifiables  []Notifiable
-	Owner        *Owner
-	isFull       bool
+	totalSlots     int
+	slots          []*Slot
+	ParkingLotId   int
+	Name           string
+	subscriptions  []*Subscription
+	Owner          *Owner
+	isFull         bool
+	history        []*OwnershipTransfer
+	accessControl  *AccessControl
+	Clock          Clock
+	occupancy      []OccupancySample
+	thresholds     []*thresholdWatch
+	overstayLimits OverstayLimits
+	stateLog       *StateLog
+	emergency      *Emergency
 }
 
 func ParkingLotConstruct(totalSlots int, owner *Owner) *ParkingLot {
+	lot, err := ParkingLotConstructChecked(totalSlots, owner)
+	if err != nil {
+		panic(err)
+	}
+	return lot
+}
+
+// ParkingLotConstructChecked is ParkingLotConstruct reporting an invalid
+// size or a missing owner as an error instead of panicking.
+func ParkingLotConstructChecked(totalSlots int, owner *Owner) (*ParkingLot, error) {
 	if totalSlots <= 0 {
-		panic(Exceptions.ErrCannotCreateParkingLotException)
+		return nil, Exceptions.ErrCannotCreateParkingLotException
 	}
 	if owner == nil {
-		panic(Exceptions.ErrParkingLotAlreadyAssigned)
+		return nil, Exceptions.ErrParkingLotNeedsOwner
 	}
 	uuidValue := uuid.New()
 	lot := &ParkingLot{
-		totalSlots:   totalSlots,
-		Owner:        owner,
-		ParkingLotId: uuidToInt(uuidValue),
-		notifiables:  []Notifiable{},
-		slots:        make([]*Slot, totalSlots),
+		totalSlots:    totalSlots,
+		Owner:         owner,
+		ParkingLotId:  uuidToInt(uuidValue),
+		subscriptions: []*Subscription{},
+		slots:         make([]*Slot, totalSlots),
 	}
 	for i := 0; i < totalSlots; i++ {
 		lot.slots[i] = SlotConstruct()
 	}
-	return lot
+	return lot, nil
 }
 
 func uuidToInt(u uuid.UUID) int {
@@ -45,43 +65,137 @@ func uuidToInt(u uuid.UUID) int {
 
 func (parkinglot *ParkingLot) findNearestSlot() (*Slot, error) {
 	for _, slot := range parkinglot.slots {
-		if slot.IsFree() {
+		if slot.IsFree() && slot.IsInService() {
 			return slot, nil
 		}
 	}
-	return nil, Exceptions.ErrParkingLotIsFull
+	return nil, Exceptions.ErrParkingLotIsFull.WithLot(parkinglot.ParkingLotId)
 }
 
+// Park parks the car without an attendent. Under access control the caller is
+// anonymous and is refused; use ParkAs to act as a principal.
 func (parkinglot *ParkingLot) Park(car *Car) (*Ticket, error) {
+	return parkinglot.ParkAs(nil, car)
+}
+
+func (parkinglot *ParkingLot) ParkAs(principal *Principal, car *Car) (*Ticket, error) {
+	if err := authorize(parkinglot.accessControl, principal, PermissionPark, parkinglot.ParkingLotId); err != nil {
+		return nil, err
+	}
+	tx := newParkingTransaction()
+	ticket, err := parkinglot.park(car, principal, tx)
+	if err != nil {
+		tx.rollback()
+		return nil, err
+	}
+	tx.commit()
+	return ticket, nil
+}
+
+func (parkinglot *ParkingLot) park(car *Car, actor *Principal, tx *parkingTransaction) (*Ticket, error) {
+	if parkinglot.InEmergency() {
+		return nil, Exceptions.ErrParkingLotInEmergency.WithLot(parkinglot.ParkingLotId).WithRegistration(car.RegistrationNumber)
+	}
 	if parkinglot.IsFull() {
-		return nil, Exceptions.ErrParkingLotIsFull
+		return nil, Exceptions.ErrParkingLotIsFull.WithLot(parkinglot.ParkingLotId).WithRegistration(car.RegistrationNumber)
 	}
 	if parkinglot.IsCarAlreadyParked(*car) {
-		return nil, Exceptions.ErrCarAlreadyParked
+		return nil, Exceptions.ErrCarAlreadyParked.WithLot(parkinglot.ParkingLotId).WithRegistration(car.RegistrationNumber)
 	}
-	slot, _ := parkinglot.findNearestSlot()
-	ticket, _ := slot.Park(*car)
-	if parkinglot.IsFull() {
-		parkinglot.isFull = true
-		parkinglot.notifyFull()
+	slot, err := parkinglot.findNearestSlot()
+	if err != nil {
+		return nil, err
+	}
+	ticket, err := slot.Park(*car)
+	if err != nil {
+		return nil, Exceptions.ErrSlotIsOccupied.WithLot(parkinglot.ParkingLotId).Wrap(err)
 	}
+	slot.parkedAt = parkinglot.now()
+	tx.onRollback(slot.release)
+	event := parkinglot.slotEvent(CarParked, slot, actor)
+	change := parkinglot.slotChange(ChangeCarParked, slot)
+	tx.afterCommit(func() {
+		parkinglot.stateLog.record(change)
+		parkinglot.publish(event)
+		parkinglot.publishOccupancy()
+	})
 	return ticket, nil
 }
 
 func (parkinglot *ParkingLot) Unpark(ticket *Ticket) (*Car, error) {
+	return parkinglot.UnparkAs(nil, ticket)
+}
+
+func (parkinglot *ParkingLot) UnparkAs(principal *Principal, ticket *Ticket) (*Car, error) {
+	if err := authorize(parkinglot.accessControl, principal, PermissionUnpark, parkinglot.ParkingLotId); err != nil {
+		return nil, err
+	}
+	tx := newParkingTransaction()
+	car, err := parkinglot.unpark(ticket, principal, tx)
+	if err != nil {
+		tx.rollback()
+		return nil, err
+	}
+	tx.commit()
+	return car, nil
+}
+
+func (parkinglot *ParkingLot) unpark(ticket *Ticket, actor *Principal, tx *parkingTransaction) (*Car, error) {
 	for _, slot := range parkinglot.slots {
+		if !slot.HoldsTicket(ticket) {
+			continue
+		}
+		event := parkinglot.slotEvent(CarUnparked, slot, actor)
+		change := parkinglot.slotChange(ChangeCarUnparked, slot)
+		heldTicket, parkedAt := slot.ticket, slot.parkedAt
 		car, err := slot.Unpark(ticket)
-		if err == nil {
-			if parkinglot.isFull && !parkinglot.IsFull() {
-				parkinglot.isFull = false
-				parkinglot.notifyAvailable()
-			}
-			if car != nil {
-				return car, nil
-			}
+		if err != nil {
+			return nil, err
 		}
+		tx.onRollback(func() { slot.occupy(*car, heldTicket, parkedAt) })
+		tx.afterCommit(func() {
+			parkinglot.stateLog.record(change)
+			parkinglot.publish(event)
+			parkinglot.publishOccupancy()
+			parkinglot.trackDeparture(event)
+		})
+		return car, nil
 	}
-	return nil, Exceptions.ErrInvalidTicket
+	return nil, Exceptions.ErrInvalidTicket.WithLot(parkinglot.ParkingLotId).WithTicket(ticketIdOf(ticket))
+}
+
+// publishOccupancy notifies subscribers when the lot crosses between full and
+// available. It runs only once a park or unpark has committed.
+func (parkinglot *ParkingLot) publishOccupancy() {
+	parkinglot.recordOccupancy()
+	parkinglot.publishThresholds()
+	isFull := parkinglot.IsFull()
+	if isFull && !parkinglot.isFull {
+		parkinglot.isFull = true
+		parkinglot.publish(parkinglot.lotEvent(LotFull))
+	}
+	if !isFull && parkinglot.isFull {
+		parkinglot.isFull = false
+		parkinglot.publish(parkinglot.lotEvent(LotAvailable))
+	}
+}
+
+func (parkinglot *ParkingLot) carFor(ticket *Ticket) (*Car, bool) {
+	for _, slot := range parkinglot.slots {
+		if slot.HoldsTicket(ticket) {
+			return slot.car, true
+		}
+	}
+	return nil, false
+}
+
+func (parkinglot *ParkingLot) SlotAddressOf(ticket *Ticket) (SlotAddress, error) {
+	for index, slot := range parkinglot.slots {
+		if slot.HoldsTicket(ticket) {
+			return SlotAddress{ParkingLotId: parkinglot.ParkingLotId, SlotNumber: index + 1}, nil
+		}
+	}
+	return SlotAddress{}, Exceptions.ErrInvalidTicket.WithLot(parkinglot.ParkingLotId).WithTicket(ticketIdOf(ticket))
 }
 
 func (parkinglot *ParkingLot) IsCarAlreadyParked(car Car) bool {
@@ -95,13 +209,22 @@ func (parkinglot *ParkingLot) IsCarAlreadyParked(car Car) bool {
 
 func (parkinglot *ParkingLot) IsFull() bool {
 	for _, slot := range parkinglot.slots {
-		if slot.IsFree() {
+		if slot.IsFree() && slot.IsInService() {
 			return false
 		}
 	}
 	return true
 }
 
+// IsFullAs is IsFull for a principal, who must be allowed to view reports at
+// the lot. The same goes for the other As queries below.
+func (parkinglot *ParkingLot) IsFullAs(principal *Principal) (bool, error) {
+	if err := authorize(parkinglot.accessControl, principal, PermissionViewReports, parkinglot.ParkingLotId); err != nil {
+		return false, err
+	}
+	return parkinglot.IsFull(), nil
+}
+
 func (parkinglot *ParkingLot) CountCarsByColor(color Enums.Color) int {
 	count := 0
 	for _, slot := range parkinglot.slots {
@@ -112,9 +235,23 @@ func (parkinglot *ParkingLot) CountCarsByColor(color Enums.Color) int {
 	return count
 }
 
+func (parkinglot *ParkingLot) CountCarsByColorAs(principal *Principal, color Enums.Color) (int, error) {
+	if err := authorize(parkinglot.accessControl, principal, PermissionViewReports, parkinglot.ParkingLotId); err != nil {
+		return 0, err
+	}
+	return parkinglot.CountCarsByColor(color), nil
+}
+
 func (parkinglot *ParkingLot) IsCarWithRegistrationNumberParked(registrationNumber string) (bool, error) {
+	return parkinglot.IsCarWithRegistrationNumberParkedAs(nil, registrationNumber)
+}
+
+func (parkinglot *ParkingLot) IsCarWithRegistrationNumberParkedAs(principal *Principal, registrationNumber string) (bool, error) {
+	if err := authorize(parkinglot.accessControl, principal, PermissionQueryRegistration, parkinglot.ParkingLotId); err != nil {
+		return false, err
+	}
 	if registrationNumber == "" {
-		return false, Exceptions.ErrCarNeedsRegistrationNumber
+		return false, Exceptions.ErrCarNeedsRegistrationNumber.WithLot(parkinglot.ParkingLotId)
 	}
 	for _, slot := range parkinglot.slots {
 		if slot.HasCarWithRegistrationNumber(registrationNumber) {
@@ -134,20 +271,66 @@ func (parkinglot *ParkingLot) CountParkedCars() int {
 	return count
 }
 
-func (parkinglot *ParkingLot) notifyFull() {
-	for _, notifiable := range parkinglot.notifiables {
-		notifiable.notifyFull(parkinglot.ParkingLotId)
+func (parkinglot *ParkingLot) CountParkedCarsAs(principal *Principal) (int, error) {
+	if err := authorize(parkinglot.accessControl, principal, PermissionViewReports, parkinglot.ParkingLotId); err != nil {
+		return 0, err
 	}
+	return parkinglot.CountParkedCars(), nil
 }
 
-func (parkinglot *ParkingLot) notifyAvailable() {
-	for _, notifiable := range parkinglot.notifiables {
-		notifiable.notifyAvailable(parkinglot.ParkingLotId)
+func (parkinglot *ParkingLot) OwnershipHistory() []*OwnershipTransfer {
+	return append([]*OwnershipTransfer{}, parkinglot.history...)
+}
+
+func (parkinglot *ParkingLot) activeTicketIds() []string {
+	ticketIds := []string{}
+	for _, slot := range parkinglot.slots {
+		if !slot.IsFree() {
+			ticketIds = append(ticketIds, slot.ticket.GetTicketId())
+		}
 	}
+	return ticketIds
 }
 
-func (parkinglot *ParkingLot) RegisterNotifiable(notifiable Notifiable) {
-	parkinglot.notifiables = append(parkinglot.notifiables, notifiable)
+// changeOwner hands the lot to a new owner, moving the old owner's
+// notification subscription over and keeping everyone else's. The lot goes
+// on writing to the new owner's state log, which starts with the lot as it
+// stands.
+func (parkinglot *ParkingLot) changeOwner(transfer *OwnershipTransfer) {
+	change := parkinglot.lotChange(ChangeOwnershipTransferred)
+	change.ToOwnerId = transfer.To.identity()
+	parkinglot.stateLog.record(change)
+	parkinglot.moveSubscriptions(transfer.From, transfer.To)
+	transfer.From.Principal.removeLot(parkinglot.ParkingLotId)
+	transfer.To.Principal.addLot(parkinglot.ParkingLotId)
+	parkinglot.Owner = transfer.To
+	parkinglot.accessControl = transfer.To.accessControl
+	parkinglot.history = append(parkinglot.history, transfer)
+	if parkinglot.stateLog != transfer.To.stateLog {
+		parkinglot.stateLog = transfer.To.stateLog
+		recordLot(parkinglot.stateLog, parkinglot)
+		for _, attendent := range append([]*Attendent{&transfer.To.Attendent}, transfer.To.Attendents...) {
+			recordAttendent(parkinglot.stateLog, attendent, []*ParkingLot{parkinglot})
+		}
+	}
+}
+
+func (parkinglot *ParkingLot) now() time.Time {
+	if parkinglot.Clock == nil {
+		return time.Now()
+	}
+	return parkinglot.Clock.Now()
+}
+
+// Capacity counts the slots currently in service.
+func (parkinglot *ParkingLot) Capacity() int {
+	capacity := 0
+	for _, slot := range parkinglot.slots {
+		if slot.IsInService() {
+			capacity++
+		}
+	}
+	return capacity
 }
 
 func (parkinglot *ParkingLot) GetParkingLotId() int {
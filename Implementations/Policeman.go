@@ -1,17 +1,141 @@
 package Implementations
 
-import "fmt"
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
 
-type Policeman struct{}
+// WatchlistMatch is the evidence kept when a watched vehicle is parked.
+type WatchlistMatch struct {
+	RegistrationNumber string
+	Reason             string
+	ParkingLotId       int
+	Slot               SlotAddress
+	TicketId           string
+	Actor              string
+	At                 time.Time
+}
+
+// Policeman watches for flagged vehicles in the lots it subscribes to. Alerts
+// are printed and, when Alerts is set, also sent on as WatchlistMatched events
+// along with any emergency declared at those lots.
+type Policeman struct {
+	Alerts    Notifiable
+	Recipient Recipient
+	delivery  *DigestNotifier
+	mutex     sync.Mutex
+	watchlist map[string]string
+	matches   []WatchlistMatch
+}
 
 func PolicemanConstruct() *Policeman {
 	return &Policeman{}
 }
 
-func (p *Policeman) notifyFull(parkingLotId int) {
-	fmt.Printf("Policeman notified: Parking lot with ID %d is full.\n", parkingLotId)
+// normalizePlate lets "ap 12-34" match "AP1234".
+func normalizePlate(registrationNumber string) string {
+	return strings.Map(func(r rune) rune {
+		if r == ' ' || r == '-' {
+			return -1
+		}
+		return r
+	}, strings.ToUpper(registrationNumber))
+}
+
+func (p *Policeman) Watch(registrationNumber string, reason string) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	if p.watchlist == nil {
+		p.watchlist = map[string]string{}
+	}
+	p.watchlist[normalizePlate(registrationNumber)] = reason
+}
+
+func (p *Policeman) Unwatch(registrationNumber string) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	delete(p.watchlist, normalizePlate(registrationNumber))
+}
+
+func (p *Policeman) IsWatching(registrationNumber string) bool {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	_, ok := p.watchlist[normalizePlate(registrationNumber)]
+	return ok
+}
+
+func (p *Policeman) Watchlist() []string {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	registrationNumbers := []string{}
+	for registrationNumber := range p.watchlist {
+		registrationNumbers = append(registrationNumbers, registrationNumber)
+	}
+	sort.Strings(registrationNumbers)
+	return registrationNumbers
+}
+
+func (p *Policeman) Matches() []WatchlistMatch {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	return append([]WatchlistMatch{}, p.matches...)
+}
+
+func (p *Policeman) SetDeliveryPreferences(preferences DeliveryPreferences, clock Clock) *DigestNotifier {
+	p.delivery = DigestNotifierConstruct(console{name: "Policeman", recipient: &p.Recipient}, preferences, clock)
+	return p.delivery
+}
+
+// Patrol subscribes the policeman to the lot's arrivals as well as to the
+// events every registered notifiable hears about. Registering the policeman
+// with the lot does the same.
+func (p *Policeman) Patrol(parkingLot *ParkingLot) *Subscription {
+	return parkingLot.RegisterNotifiable(p)
+}
+
+func (p *Policeman) registeredEventTypes() []EventType {
+	return append([]EventType{CarParked}, defaultEventTypes...)
 }
 
-func (p *Policeman) notifyAvailable(parkingLotId int) {
-	fmt.Printf("Policeman notified: Parking lot with ID %d has available slots.\n", parkingLotId)
+func (p *Policeman) Notify(event Event) {
+	if event.Type != CarParked {
+		if event.Type == EmergencyDeclared && p.Alerts != nil {
+			p.Alerts.Notify(event)
+		}
+		if p.delivery != nil {
+			p.delivery.Notify(event)
+			return
+		}
+		console{name: "Policeman", recipient: &p.Recipient}.Notify(event)
+		return
+	}
+	if event.Vehicle == nil {
+		return
+	}
+	p.mutex.Lock()
+	reason, watched := p.watchlist[normalizePlate(event.Vehicle.RegistrationNumber)]
+	if watched {
+		p.matches = append(p.matches, WatchlistMatch{
+			RegistrationNumber: event.Vehicle.RegistrationNumber,
+			Reason:             reason,
+			ParkingLotId:       event.ParkingLotId,
+			Slot:               event.Slot,
+			TicketId:           event.TicketId,
+			Actor:              event.Actor,
+			At:                 event.At,
+		})
+	}
+	p.mutex.Unlock()
+	if !watched {
+		return
+	}
+	alert := event
+	alert.Type = WatchlistMatched
+	fmt.Printf("Policeman alerted: %s (%s)\n", renderMessage(DefaultCatalog, alert, p.Recipient), reason)
+	if p.Alerts != nil {
+		p.Alerts.Notify(alert)
+	}
 }
@@ -0,0 +1,86 @@
+package Implementations
+
+import (
+	"ParkingLot_go/Enums"
+	"sort"
+	"time"
+)
+
+type LotOccupancy struct {
+	ParkingLotId int
+	Capacity     int
+	Occupied     int
+	Free         int
+	PercentFull  float64
+	IsFull       bool
+	Series       []OccupancySample
+}
+
+// PortfolioSummary is an owner-wide snapshot of occupancy across all of the
+// owner's lots, shaped for a dashboard to render.
+type PortfolioSummary struct {
+	GeneratedAt   time.Time
+	TotalCapacity int
+	Occupied      int
+	Free          int
+	PercentFull   float64
+	Lots          []LotOccupancy
+	FullLots      []int
+	ByColor       map[Enums.Color]int
+	ByVehicleType map[Enums.VehicleType]int
+	LongestParked []ParkedVehicle
+}
+
+// Portfolio summarises the owner's lots, listing at most longestParked of the
+// vehicles that have been parked the longest.
+func (owner *Owner) Portfolio(longestParked int) (PortfolioSummary, error) {
+	if err := owner.authorizeGlobal(PermissionViewReports); err != nil {
+		return PortfolioSummary{}, err
+	}
+	now := owner.now()
+	summary := PortfolioSummary{
+		GeneratedAt:   now,
+		Lots:          []LotOccupancy{},
+		FullLots:      []int{},
+		ByColor:       map[Enums.Color]int{},
+		ByVehicleType: map[Enums.VehicleType]int{},
+		LongestParked: []ParkedVehicle{},
+	}
+	vehicles := []ParkedVehicle{}
+	for _, parkingLot := range owner.OwnerParkingLots {
+		occupied := parkingLot.CountParkedCars()
+		lot := LotOccupancy{
+			ParkingLotId: parkingLot.GetParkingLotId(),
+			Capacity:     parkingLot.Capacity(),
+			Occupied:     occupied,
+			Free:         parkingLot.Capacity() - occupied,
+			PercentFull:  parkingLot.PercentFull(),
+			IsFull:       parkingLot.IsFull(),
+			Series:       parkingLot.OccupancySeries(),
+		}
+		summary.Lots = append(summary.Lots, lot)
+		summary.TotalCapacity += lot.Capacity
+		summary.Occupied += lot.Occupied
+		summary.Free += lot.Free
+		if lot.IsFull {
+			summary.FullLots = append(summary.FullLots, lot.ParkingLotId)
+		}
+		for _, vehicle := range parkingLot.parkedVehicles(now) {
+			summary.ByColor[vehicle.Car.Color]++
+			summary.ByVehicleType[vehicle.Car.TypeOfVehicle()]++
+			vehicles = append(vehicles, vehicle)
+		}
+	}
+	summary.PercentFull = percentOf(summary.Occupied, summary.TotalCapacity)
+	sort.SliceStable(vehicles, func(i, j int) bool {
+		return vehicles[i].ParkedAt.Before(vehicles[j].ParkedAt)
+	})
+	if longestParked < 0 {
+		longestParked = 0
+	}
+	if longestParked < len(vehicles) {
+		vehicles = vehicles[:longestParked]
+	}
+	summary.LongestParked = append(summary.LongestParked, vehicles...)
+	return summary, nil
+}
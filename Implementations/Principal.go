@@ -0,0 +1,111 @@
+package Implementations
+
+type Permission string
+
+const (
+	PermissionCreateLot         Permission = "CREATE_LOT"
+	PermissionAssignAttendent   Permission = "ASSIGN_ATTENDENT"
+	PermissionPark              Permission = "PARK"
+	PermissionUnpark            Permission = "UNPARK"
+	PermissionQueryRegistration Permission = "QUERY_REGISTRATION"
+	PermissionViewReports       Permission = "VIEW_REPORTS"
+	PermissionManageLot         Permission = "MANAGE_LOT"
+)
+
+type Role string
+
+const (
+	RoleOwner      Role = "OWNER"
+	RoleAttendent  Role = "ATTENDENT"
+	RolePolice     Role = "POLICE"
+	RoleSupervisor Role = "SUPERVISOR"
+)
+
+// lotScopedRoles grant their permissions only at the lots the principal has
+// been given. Checks that are not about any lot are granted by the role alone.
+var lotScopedRoles = map[Role]bool{
+	RoleOwner:      true,
+	RoleAttendent:  true,
+	RoleSupervisor: true,
+}
+
+// Principal is whoever performs an operation. A nil principal is anonymous.
+// ParkingLotIds are the lots where its lot-scoped roles count; they follow
+// the lots the owner creates and the attendent is assigned. A supervisor's
+// principal also has every lot its team attends.
+type Principal struct {
+	Name          string
+	Roles         []Role
+	ParkingLotIds []int
+	supervisor    *Supervisor
+}
+
+func PrincipalConstruct(name string, roles ...Role) *Principal {
+	return &Principal{
+		Name:  name,
+		Roles: append([]Role{}, roles...),
+	}
+}
+
+func (principal *Principal) HasRole(role Role) bool {
+	if principal == nil {
+		return false
+	}
+	for _, held := range principal.Roles {
+		if held == role {
+			return true
+		}
+	}
+	return false
+}
+
+// grants reports whether the role lets the principal act at the lot.
+func (principal *Principal) grants(role Role, parkingLotId int) bool {
+	return !lotScopedRoles[role] || principal.hasLot(parkingLotId)
+}
+
+func (principal *Principal) hasLot(parkingLotId int) bool {
+	if principal == nil {
+		return false
+	}
+	for _, id := range principal.ParkingLotIds {
+		if id == parkingLotId {
+			return true
+		}
+	}
+	if principal.supervisor != nil {
+		for _, lot := range principal.supervisor.AssignedLots() {
+			if lot.GetParkingLotId() == parkingLotId {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func (principal *Principal) addLot(parkingLotId int) {
+	if principal == nil || principal.hasLot(parkingLotId) {
+		return
+	}
+	principal.ParkingLotIds = append(principal.ParkingLotIds, parkingLotId)
+}
+
+func (principal *Principal) removeLot(parkingLotId int) {
+	if principal == nil {
+		return
+	}
+	remaining := []int{}
+	for _, id := range principal.ParkingLotIds {
+		if id != parkingLotId {
+			remaining = append(remaining, id)
+		}
+	}
+	principal.ParkingLotIds = remaining
+}
+
+func (principal *Principal) displayName() string {
+	if principal == nil {
+		return "anonymous"
+	}
+	return principal.Name
+}
@@ -0,0 +1,23 @@
+package Implementations
+
+import "time"
+
+type RevocationKind string
+
+const (
+	LotRevoked     RevocationKind = "LOT_REVOKED"
+	AttendentFired RevocationKind = "ATTENDENT_FIRED"
+)
+
+// Revocation records a lot taken away from an attendent, or an attendent
+// let go, and where the cars it was holding went. A nil TransferredTo means
+// the owner took the cars over directly. Each lot involved also publishes an
+// AttendentRevoked event.
+type Revocation struct {
+	Kind          RevocationKind
+	Attendent     *Attendent
+	ParkingLotIds []int
+	TransferredTo *Attendent
+	TicketIds     []string
+	At            time.Time
+}
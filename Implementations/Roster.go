@@ -0,0 +1,173 @@
+package Implementations
+
+import (
+	"ParkingLot_go/Exceptions"
+	"time"
+)
+
+type Handover struct {
+	From      *Attendent
+	To        *Attendent
+	At        time.Time
+	TicketIds []string
+}
+
+// Roster schedules attendent shifts. An attendent on a roster may only park
+// while clocked in to one of its shifts, and only until that shift ends. An
+// attendent can be on one roster at a time.
+type Roster struct {
+	clock         Clock
+	shifts        []*Shift
+	Handovers     []*Handover
+	AccessControl *AccessControl
+}
+
+func RosterConstruct(clock Clock) *Roster {
+	if clock == nil {
+		clock = SystemClock{}
+	}
+	return &Roster{
+		clock:     clock,
+		shifts:    []*Shift{},
+		Handovers: []*Handover{},
+	}
+}
+
+func (roster *Roster) Schedule(attendent *Attendent, start time.Time, end time.Time) (*Shift, error) {
+	if !end.After(start) {
+		return nil, Exceptions.ErrInvalidShift
+	}
+	if attendent.roster != nil && attendent.roster != roster {
+		return nil, Exceptions.ErrAttendentOnAnotherRoster
+	}
+	shift := &Shift{Attendent: attendent, Start: start, End: end}
+	for _, existing := range roster.ShiftsOf(attendent) {
+		if existing.overlaps(shift) {
+			return nil, Exceptions.ErrShiftOverlaps
+		}
+	}
+	roster.shifts = append(roster.shifts, shift)
+	attendent.roster = roster
+	return shift, nil
+}
+
+func (roster *Roster) ShiftsOf(attendent *Attendent) []*Shift {
+	shifts := []*Shift{}
+	for _, shift := range roster.shifts {
+		if shift.Attendent == attendent {
+			shifts = append(shifts, shift)
+		}
+	}
+	return shifts
+}
+
+func (roster *Roster) ClockIn(attendent *Attendent) (*Shift, error) {
+	if roster.activeShift(attendent) != nil {
+		return nil, Exceptions.ErrAlreadyClockedIn
+	}
+	now := roster.clock.Now()
+	for _, shift := range roster.ShiftsOf(attendent) {
+		if shift.covers(now) && shift.ClockedInAt.IsZero() {
+			shift.ClockedInAt = now
+			return shift, nil
+		}
+	}
+	return nil, Exceptions.ErrNoScheduledShift
+}
+
+// ClockOut ends the shift the attendent is clocked in to, even one that has
+// already ended. Cars still held by the attendent must be handed over first.
+func (roster *Roster) ClockOut(attendent *Attendent) (*Shift, error) {
+	shift := roster.clockedInShift(attendent)
+	if shift == nil {
+		return nil, Exceptions.ErrNotClockedIn
+	}
+	if len(attendent.tickets) > 0 {
+		return nil, Exceptions.ErrPendingHandover
+	}
+	shift.ClockedOutAt = roster.clock.Now()
+	return shift, nil
+}
+
+func (roster *Roster) IsOnDuty(attendent *Attendent) bool {
+	return roster.activeShift(attendent) != nil
+}
+
+// Handover moves responsibility for every car and open ticket held by the
+// outgoing attendent to the incoming one, along with any lots it lacks, each
+// on behalf of the lot's owner. If any lot cannot be assigned nothing moves.
+func (roster *Roster) Handover(outgoing *Attendent, incoming *Attendent) (*Handover, error) {
+	incomingShift := roster.activeShift(incoming)
+	if incomingShift == nil {
+		return nil, Exceptions.ErrNotClockedIn
+	}
+	tx := newParkingTransaction()
+	for _, lot := range outgoing.AssignedParkingLots {
+		if contains(incoming.AssignedParkingLots, lot) {
+			continue
+		}
+		if err := incoming.assign(lot, lot.Owner, tx); err != nil {
+			tx.rollback()
+			return nil, err
+		}
+	}
+	ticketIds := outgoing.handOverTo(incoming, tx)
+	tx.commit()
+	handover := &Handover{From: outgoing, To: incoming, At: roster.clock.Now(), TicketIds: ticketIds}
+	roster.Handovers = append(roster.Handovers, handover)
+	if outgoingShift := roster.clockedInShift(outgoing); outgoingShift != nil {
+		outgoingShift.CarsHandedOver += len(ticketIds)
+	}
+	incomingShift.CarsReceived += len(ticketIds)
+	return handover, nil
+}
+
+func (roster *Roster) ShiftReports(attendent *Attendent) []ShiftReport {
+	now := roster.clock.Now()
+	reports := []ShiftReport{}
+	for _, shift := range roster.ShiftsOf(attendent) {
+		reports = append(reports, shift.Report(now))
+	}
+	return reports
+}
+
+func (roster *Roster) ShiftReportsAs(viewer *Principal, attendent *Attendent) ([]ShiftReport, error) {
+	if err := authorizeGlobal(roster.AccessControl, viewer, PermissionViewReports); err != nil {
+		return nil, err
+	}
+	return roster.ShiftReports(attendent), nil
+}
+
+func (roster *Roster) activeShift(attendent *Attendent) *Shift {
+	now := roster.clock.Now()
+	for _, shift := range roster.ShiftsOf(attendent) {
+		if shift.IsActive(now) {
+			return shift
+		}
+	}
+	return nil
+}
+
+// clockedInShift is the latest shift the attendent has not clocked out of,
+// whether or not it has ended.
+func (roster *Roster) clockedInShift(attendent *Attendent) *Shift {
+	var latest *Shift
+	for _, shift := range roster.ShiftsOf(attendent) {
+		if shift.clockedIn() && (latest == nil || shift.Start.After(latest.Start)) {
+			latest = shift
+		}
+	}
+	return latest
+}
+
+func (roster *Roster) recordPark(attendent *Attendent) {
+	if shift := roster.activeShift(attendent); shift != nil {
+		shift.CarsParked++
+	}
+}
+
+func (roster *Roster) recordUnpark(attendent *Attendent) {
+	if shift := roster.activeShift(attendent); shift != nil {
+		shift.CarsUnparked++
+	}
+}
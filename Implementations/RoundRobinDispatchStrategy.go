@@ -0,0 +1,26 @@
+package Implementations
+
+import (
+	"ParkingLot_go/Exceptions"
+)
+
+// RoundRobinDispatchStrategy takes the owner's attendents in turn, skipping
+// those unavailable, so the rotation does not shift as they come and go.
+type RoundRobinDispatchStrategy struct {
+	next int
+}
+
+func (r *RoundRobinDispatchStrategy) SelectAttendent(availableAttendents []*Attendent, car *Car) (*Attendent, error) {
+	return r.selectFromRoster(availableAttendents, func(*Attendent) bool { return true }, car)
+}
+
+func (r *RoundRobinDispatchStrategy) selectFromRoster(roster []*Attendent, isAvailable func(attendent *Attendent) bool, car *Car) (*Attendent, error) {
+	for offset := range roster {
+		index := (r.next + offset) % len(roster)
+		if isAvailable(roster[index]) {
+			r.next = index + 1
+			return roster[index], nil
+		}
+	}
+	return nil, Exceptions.ErrNoAttendentAvailable.WithRegistration(car.RegistrationNumber)
+}
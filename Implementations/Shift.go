@@ -0,0 +1,77 @@
+package Implementations
+
+import "time"
+
+type Shift struct {
+	Attendent      *Attendent
+	Start          time.Time
+	End            time.Time
+	ClockedInAt    time.Time
+	ClockedOutAt   time.Time
+	CarsParked     int
+	CarsUnparked   int
+	CarsHandedOver int
+	CarsReceived   int
+}
+
+type ShiftReport struct {
+	Start          time.Time
+	End            time.Time
+	ClockedInAt    time.Time
+	ClockedOutAt   time.Time
+	LateBy         time.Duration
+	Worked         time.Duration
+	CarsParked     int
+	CarsUnparked   int
+	CarsHandedOver int
+	CarsReceived   int
+}
+
+func (shift *Shift) covers(at time.Time) bool {
+	return !at.Before(shift.Start) && at.Before(shift.End)
+}
+
+func (shift *Shift) overlaps(other *Shift) bool {
+	return shift.Start.Before(other.End) && other.Start.Before(shift.End)
+}
+
+// IsActive reports whether the attendent is clocked in to the shift and the
+// shift has not yet ended at the given moment.
+func (shift *Shift) IsActive(at time.Time) bool {
+	return shift.clockedIn() && at.Before(shift.End)
+}
+
+func (shift *Shift) clockedIn() bool {
+	return !shift.ClockedInAt.IsZero() && shift.ClockedOutAt.IsZero()
+}
+
+// Report summarises the shift. For a shift the attendent has not clocked out
+// of, the worked time runs up to the given moment or the end of the shift,
+// whichever comes first.
+func (shift *Shift) Report(now time.Time) ShiftReport {
+	report := ShiftReport{
+		Start:          shift.Start,
+		End:            shift.End,
+		ClockedInAt:    shift.ClockedInAt,
+		ClockedOutAt:   shift.ClockedOutAt,
+		CarsParked:     shift.CarsParked,
+		CarsUnparked:   shift.CarsUnparked,
+		CarsHandedOver: shift.CarsHandedOver,
+		CarsReceived:   shift.CarsReceived,
+	}
+	if shift.ClockedInAt.IsZero() {
+		return report
+	}
+	if shift.ClockedInAt.After(shift.Start) {
+		report.LateBy = shift.ClockedInAt.Sub(shift.Start)
+	}
+	until := shift.ClockedOutAt
+	if until.IsZero() {
+		until = now
+		if until.After(shift.End) {
+			until = shift.End
+		}
+	}
+	report.Worked = until.Sub(shift.ClockedInAt)
+	return report
+}
@@ -2,12 +2,16 @@ package Implementations
 
 import (
 	"ParkingLot_go/Enums"
-	"errors"
+	"ParkingLot_go/Exceptions"
+	"time"
 )
 
 type Slot struct {
-	car    *Car
-	ticket *Ticket
+	car          *Car
+	ticket       *Ticket
+	parkedAt     time.Time
+	outOfService bool
+	slotType     Enums.SlotType
 }
 
 func SlotConstruct() *Slot {
@@ -21,9 +25,24 @@ func (s *Slot) IsFree() bool {
 	return s.car == nil
 }
 
+// Type treats slots that were never given a type as standard slots.
+func (s *Slot) Type() Enums.SlotType {
+	if s.slotType == "" {
+		return Enums.STANDARD
+	}
+	return s.slotType
+}
+
+func (s *Slot) IsInService() bool {
+	return !s.outOfService
+}
+
 func (s *Slot) Park(car Car) (*Ticket, error) {
 	if !s.IsFree() {
-		return nil, errors.New("slot is already occupied")
+		return nil, Exceptions.ErrSlotIsOccupied.WithRegistration(car.RegistrationNumber)
+	}
+	if !s.IsInService() {
+		return nil, Exceptions.ErrSlotOutOfService.WithRegistration(car.RegistrationNumber)
 	}
 	s.car = &car
 	s.ticket = TicketConstruct()
@@ -32,15 +51,32 @@ func (s *Slot) Park(car Car) (*Ticket, error) {
 
 func (s *Slot) Unpark(ticket *Ticket) (*Car, error) {
 	if s.IsFree() {
-		return nil, errors.New("car not found in the slot")
+		return nil, Exceptions.ErrSlotIsEmpty.WithTicket(ticketIdOf(ticket))
 	}
-	if s.ticket.Equals(ticket) {
+	if s.HoldsTicket(ticket) {
 		car := s.car
 		s.car = nil
 		s.ticket = nil
+		s.parkedAt = time.Time{}
 		return car, nil
 	}
-	return nil, errors.New("invalid ticket")
+	return nil, Exceptions.ErrInvalidTicket.WithTicket(ticketIdOf(ticket))
+}
+
+func (s *Slot) occupy(car Car, ticket *Ticket, parkedAt time.Time) {
+	s.car = &car
+	s.ticket = ticket
+	s.parkedAt = parkedAt
+}
+
+func (s *Slot) release() {
+	s.car = nil
+	s.ticket = nil
+	s.parkedAt = time.Time{}
+}
+
+func (s *Slot) HoldsTicket(ticket *Ticket) bool {
+	return !s.IsFree() && ticket != nil && s.ticket.Equals(ticket)
 }
 
 func (s *Slot) HasCarOfColor(color Enums.Color) bool {
@@ -0,0 +1,12 @@
+package Implementations
+
+import "fmt"
+
+type SlotAddress struct {
+	ParkingLotId int
+	SlotNumber   int
+}
+
+func (address SlotAddress) String() string {
+	return fmt.Sprintf("lot %d, slot %d", address.ParkingLotId, address.SlotNumber)
+}
@@ -1,7 +1,7 @@
 package Implementations
 
 import (
-	"errors"
+	"ParkingLot_go/Exceptions"
 )
 
 type SmartNextLotStrategy struct{}
@@ -18,7 +18,7 @@ func (s *SmartNextLotStrategy) GetNextLot(assignedParkingLots []*ParkingLot) (*P
 	}
 
 	if selectedLot == nil {
-		return nil, errors.New("all parking lots are full")
+		return nil, Exceptions.ErrAllParkingLotsFull
 	}
 	return selectedLot, nil
 }
@@ -0,0 +1,264 @@
+package Implementations
+
+import (
+	"ParkingLot_go/Enums"
+	"encoding/json"
+	"reflect"
+	"sort"
+	"time"
+)
+
+// SnapshotVersion is written into every snapshot. Restoring a snapshot of any
+// other version fails.
+const SnapshotVersion = 1
+
+// OwnerSubscriber names the owner itself among the subscribers of a snapshot.
+const OwnerSubscriber = "owner"
+
+// OwnerSnapshot is the JSON form of an owner with its lots, the cars and
+// tickets in them, its attendents and the subscriptions to its lots. Self
+// carries the owner's principal. Clocks, the access audit log, the state log's
+// history and the attendents' decision records and metrics are not saved.
+type OwnerSnapshot struct {
+	Version          int                    `json:"version"`
+	TakenAt          time.Time              `json:"takenAt"`
+	OwnerId          string                 `json:"ownerId"`
+	Self             AttendentSnapshot      `json:"self"`
+	DispatchStrategy string                 `json:"dispatchStrategy,omitempty"`
+	Locale           Locale                 `json:"locale,omitempty"`
+	TimeZone         string                 `json:"timeZone,omitempty"`
+	EmergencyReason  *string                `json:"emergencyReason,omitempty"`
+	AccessControl    *AccessControlSnapshot `json:"accessControl,omitempty"`
+	StateLogged      bool                   `json:"stateLogged,omitempty"`
+	ParkingLots      []ParkingLotSnapshot   `json:"parkingLots"`
+	Attendents       []AttendentSnapshot    `json:"attendents"`
+	Subscriptions    []SubscriptionSnapshot `json:"subscriptions"`
+}
+
+// AccessControlSnapshot holds the permissions granted to each role.
+type AccessControlSnapshot struct {
+	RolePermissions map[Role][]Permission `json:"rolePermissions"`
+}
+
+type ParkingLotSnapshot struct {
+	Id             int                  `json:"id"`
+	Name           string               `json:"name,omitempty"`
+	Slots          []SlotSnapshot       `json:"slots"`
+	Thresholds     []OccupancyThreshold `json:"thresholds,omitempty"`
+	OverstayLimits OverstayLimits       `json:"overstayLimits"`
+	Emergency      *EmergencySnapshot   `json:"emergency,omitempty"`
+}
+
+type SlotSnapshot struct {
+	Type         Enums.SlotType `json:"type,omitempty"`
+	OutOfService bool           `json:"outOfService,omitempty"`
+	Car          *Car           `json:"car,omitempty"`
+	TicketId     string         `json:"ticketId,omitempty"`
+	ParkedAt     *time.Time     `json:"parkedAt,omitempty"`
+}
+
+type EmergencySnapshot struct {
+	Reason     string              `json:"reason"`
+	DeclaredAt time.Time           `json:"declaredAt"`
+	Vehicles   []EvacuatingVehicle `json:"vehicles"`
+}
+
+// AttendentSnapshot holds the attendent's part in this owner's lots only.
+// Employed is set for attendents the owner hired rather than contracted.
+type AttendentSnapshot struct {
+	Id                 string             `json:"id"`
+	Employed           bool               `json:"employed,omitempty"`
+	Principal          *Principal         `json:"principal,omitempty"`
+	NextLotStrategy    string             `json:"nextLotStrategy,omitempty"`
+	DistanceToEntrance int                `json:"distanceToEntrance,omitempty"`
+	ParkingLotIds      []int              `json:"parkingLotIds"`
+	TicketIds          []string           `json:"ticketIds"`
+	Contracts          []ContractSnapshot `json:"contracts,omitempty"`
+}
+
+type ContractSnapshot struct {
+	ParkingLotIds []int     `json:"parkingLotIds"`
+	Start         time.Time `json:"start"`
+	End           time.Time `json:"end"`
+	FeePerPark    int64     `json:"feePerPark"`
+}
+
+// SubscriptionSnapshot refers to its subscriber by the name it was given when
+// the snapshot was taken. AllLots marks a subscription made with SubscribeAll,
+// and Thresholds one made with SubscribeThresholds at its only lot.
+type SubscriptionSnapshot struct {
+	Subscriber    string               `json:"subscriber"`
+	Filter        EventFilter          `json:"filter"`
+	AllLots       bool                 `json:"allLots,omitempty"`
+	ParkingLotIds []int                `json:"parkingLotIds,omitempty"`
+	Thresholds    []OccupancyThreshold `json:"thresholds,omitempty"`
+}
+
+// Snapshot serializes the owner. Subscriptions are saved for the owner itself
+// and for the subscribers named in subscribers; anyone else's are left out,
+// as there is no way to write them down.
+func (owner *Owner) Snapshot(subscribers map[string]Notifiable) ([]byte, error) {
+	if err := owner.authorizeGlobal(PermissionViewReports); err != nil {
+		return nil, err
+	}
+	snapshot := OwnerSnapshot{
+		Version:          SnapshotVersion,
+		TakenAt:          owner.now(),
+		OwnerId:          owner.identity(),
+		Self:             owner.snapshotAttendent(&owner.Attendent),
+		DispatchStrategy: typeName(owner.DispatchStrategy),
+		Locale:           owner.Recipient.Locale,
+		EmergencyReason:  owner.emergencyReason,
+		StateLogged:      owner.stateLog != nil,
+		ParkingLots:      []ParkingLotSnapshot{},
+		Attendents:       []AttendentSnapshot{},
+		Subscriptions:    owner.snapshotSubscriptions(subscribers),
+	}
+	if owner.Recipient.Location != nil {
+		snapshot.TimeZone = owner.Recipient.Location.String()
+	}
+	if owner.accessControl != nil {
+		snapshot.AccessControl = &AccessControlSnapshot{RolePermissions: owner.accessControl.rolePermissions}
+	}
+	for _, lot := range owner.OwnerParkingLots {
+		snapshot.ParkingLots = append(snapshot.ParkingLots, snapshotParkingLot(lot))
+	}
+	for _, attendent := range owner.Attendents {
+		snapshot.Attendents = append(snapshot.Attendents, owner.snapshotAttendent(attendent))
+	}
+	return json.MarshalIndent(snapshot, "", "  ")
+}
+
+func snapshotParkingLot(parkingLot *ParkingLot) ParkingLotSnapshot {
+	snapshot := ParkingLotSnapshot{
+		Id:             parkingLot.ParkingLotId,
+		Name:           parkingLot.Name,
+		Slots:          []SlotSnapshot{},
+		OverstayLimits: parkingLot.overstayLimits,
+	}
+	for _, slot := range parkingLot.slots {
+		slotSnapshot := SlotSnapshot{Type: slot.slotType, OutOfService: slot.outOfService}
+		if !slot.IsFree() {
+			car, parkedAt := *slot.car, slot.parkedAt
+			slotSnapshot.Car = &car
+			slotSnapshot.TicketId = slot.ticket.GetTicketId()
+			slotSnapshot.ParkedAt = &parkedAt
+		}
+		snapshot.Slots = append(snapshot.Slots, slotSnapshot)
+	}
+	for _, watch := range parkingLot.thresholds {
+		if watch.subscription == nil {
+			snapshot.Thresholds = append(snapshot.Thresholds, watch.threshold)
+		}
+	}
+	if emergency := parkingLot.emergency; emergency != nil {
+		snapshot.Emergency = &EmergencySnapshot{
+			Reason:     emergency.Reason,
+			DeclaredAt: emergency.DeclaredAt,
+			Vehicles:   emergency.Vehicles(),
+		}
+	}
+	return snapshot
+}
+
+func (owner *Owner) snapshotAttendent(attendent *Attendent) AttendentSnapshot {
+	snapshot := AttendentSnapshot{
+		Id:                 attendent.identity(),
+		Employed:           attendent.AssignedOwner == owner,
+		Principal:          attendent.Principal,
+		NextLotStrategy:    strategyName(attendent.NextLotStrategy),
+		DistanceToEntrance: attendent.DistanceToEntrance,
+		ParkingLotIds:      []int{},
+		TicketIds:          []string{},
+	}
+	for _, lot := range attendent.AssignedParkingLots {
+		if contains(owner.OwnerParkingLots, lot) {
+			snapshot.ParkingLotIds = append(snapshot.ParkingLotIds, lot.ParkingLotId)
+		}
+	}
+	for ticketId, attended := range attendent.tickets {
+		if contains(owner.OwnerParkingLots, attended.lot) {
+			snapshot.TicketIds = append(snapshot.TicketIds, ticketId)
+		}
+	}
+	sort.Strings(snapshot.TicketIds)
+	for _, contract := range attendent.Contracts {
+		if contract.Owner == owner {
+			snapshot.Contracts = append(snapshot.Contracts, ContractSnapshot{
+				ParkingLotIds: parkingLotIds(contract.ParkingLots),
+				Start:         contract.Start,
+				End:           contract.End,
+				FeePerPark:    contract.FeePerPark,
+			})
+		}
+	}
+	return snapshot
+}
+
+func (owner *Owner) snapshotSubscriptions(subscribers map[string]Notifiable) []SubscriptionSnapshot {
+	nameOf := func(notifiable Notifiable) string {
+		if sameNotifiable(notifiable, owner) {
+			return OwnerSubscriber
+		}
+		for name, subscriber := range subscribers {
+			if sameNotifiable(notifiable, subscriber) {
+				return name
+			}
+		}
+		return ""
+	}
+	snapshots := []SubscriptionSnapshot{}
+	for _, subscription := range owner.subscriptions {
+		if name := nameOf(subscription.notifiable); name != "" {
+			snapshots = append(snapshots, SubscriptionSnapshot{Subscriber: name, Filter: subscription.filter, AllLots: true})
+		}
+	}
+	seen := map[*Subscription]bool{}
+	for _, lot := range owner.OwnerParkingLots {
+		for _, subscription := range lot.subscriptions {
+			if subscription.owner != nil || seen[subscription] {
+				continue
+			}
+			seen[subscription] = true
+			if name := nameOf(subscription.notifiable); name != "" {
+				snapshots = append(snapshots, SubscriptionSnapshot{
+					Subscriber:    name,
+					Filter:        subscription.filter,
+					ParkingLotIds: parkingLotIds(subscription.lots),
+				})
+			}
+		}
+		for _, subscription := range lot.thresholdSubscriptions() {
+			if name := nameOf(subscription.notifiable); name != "" {
+				snapshots = append(snapshots, SubscriptionSnapshot{
+					Subscriber:    name,
+					Filter:        subscription.filter,
+					ParkingLotIds: []int{lot.ParkingLotId},
+					Thresholds:    subscription.thresholds(),
+				})
+			}
+		}
+	}
+	return snapshots
+}
+
+func parkingLotIds(parkingLots []*ParkingLot) []int {
+	ids := []int{}
+	for _, lot := range parkingLots {
+		ids = append(ids, lot.ParkingLotId)
+	}
+	return ids
+}
+
+// typeName names the concrete type behind an interface value, so strategies
+// can be written down and looked up again.
+func typeName(value interface{}) string {
+	if value == nil {
+		return ""
+	}
+	valueType := reflect.TypeOf(value)
+	if valueType.Kind() == reflect.Ptr {
+		valueType = valueType.Elem()
+	}
+	return valueType.Name()
+}
@@ -0,0 +1,208 @@
+package Implementations
+
+import (
+	"ParkingLot_go/Exceptions"
+	"encoding/json"
+	"time"
+)
+
+var nextLotStrategies = map[string]func() NextLotStrategy{
+	"NormalNextLotStrategy": func() NextLotStrategy { return &NormalNextLotStrategy{} },
+	"SmartNextLotStrategy":  func() NextLotStrategy { return &SmartNextLotStrategy{} },
+}
+
+var dispatchStrategies = map[string]func() DispatchStrategy{
+	"LeastBusyDispatchStrategy":       func() DispatchStrategy { return &LeastBusyDispatchStrategy{} },
+	"RoundRobinDispatchStrategy":      func() DispatchStrategy { return &RoundRobinDispatchStrategy{} },
+	"NearestEntranceDispatchStrategy": func() DispatchStrategy { return &NearestEntranceDispatchStrategy{} },
+}
+
+// RestoreOwner rebuilds an owner from a snapshot taken by Owner.Snapshot.
+// Tickets keep their IDs, so tickets handed out before the snapshot still
+// work. subscribers must name every subscriber the snapshot refers to other
+// than the owner. Strategies this package does not know are replaced by the
+// defaults. An owner that was under access control is put under a new one
+// granting the same permissions, and one that kept a state log starts a new
+// log from the restored state.
+func RestoreOwner(data []byte, subscribers map[string]Notifiable) (*Owner, error) {
+	var snapshot OwnerSnapshot
+	if err := json.Unmarshal(data, &snapshot); err != nil {
+		return nil, Exceptions.ErrInvalidSnapshot.Wrap(err)
+	}
+	if snapshot.Version != SnapshotVersion {
+		return nil, Exceptions.ErrUnsupportedSnapshotVersion
+	}
+	owner := OwnerConstruct()
+	owner.Id = snapshot.OwnerId
+	owner.emergencyReason = snapshot.EmergencyReason
+	owner.Recipient.Locale = snapshot.Locale
+	if snapshot.TimeZone != "" {
+		location, err := time.LoadLocation(snapshot.TimeZone)
+		if err != nil {
+			return nil, Exceptions.ErrInvalidSnapshot.Wrap(err)
+		}
+		owner.Recipient.Location = location
+	}
+	if newStrategy, ok := dispatchStrategies[snapshot.DispatchStrategy]; ok {
+		owner.DispatchStrategy = newStrategy()
+	}
+
+	lots := map[int]*ParkingLot{}
+	for _, lotSnapshot := range snapshot.ParkingLots {
+		lot, err := restoreParkingLot(lotSnapshot, owner)
+		if err != nil {
+			return nil, err
+		}
+		lots[lot.ParkingLotId] = lot
+		owner.OwnerParkingLots = append(owner.OwnerParkingLots, lot)
+	}
+	if err := owner.restoreAttendent(&owner.Attendent, snapshot.Self, lots); err != nil {
+		return nil, err
+	}
+	for _, attendentSnapshot := range snapshot.Attendents {
+		attendent := AttendentConstructDefault()
+		if err := owner.restoreAttendent(attendent, attendentSnapshot, lots); err != nil {
+			return nil, err
+		}
+		owner.Attendents = append(owner.Attendents, attendent)
+	}
+	for _, subscriptionSnapshot := range snapshot.Subscriptions {
+		if err := owner.restoreSubscription(subscriptionSnapshot, subscribers, lots); err != nil {
+			return nil, err
+		}
+	}
+	if snapshot.AccessControl != nil {
+		accessControl := AccessControlConstruct(nil)
+		accessControl.rolePermissions = map[Role][]Permission{}
+		for role, permissions := range snapshot.AccessControl.RolePermissions {
+			accessControl.rolePermissions[role] = append([]Permission{}, permissions...)
+		}
+		owner.EnableAccessControl(accessControl)
+	}
+	if snapshot.StateLogged {
+		owner.EnableStateLog(StateLogConstruct())
+	}
+	return owner, nil
+}
+
+func restoreParkingLot(snapshot ParkingLotSnapshot, owner *Owner) (*ParkingLot, error) {
+	lot, err := ParkingLotConstructChecked(len(snapshot.Slots), owner)
+	if err != nil {
+		return nil, Exceptions.ErrInvalidSnapshot.WithLot(snapshot.Id).Wrap(err)
+	}
+	lot.ParkingLotId = snapshot.Id
+	lot.Name = snapshot.Name
+	lot.overstayLimits = snapshot.OverstayLimits
+	for index, slotSnapshot := range snapshot.Slots {
+		slot := lot.slots[index]
+		slot.slotType = slotSnapshot.Type
+		slot.outOfService = slotSnapshot.OutOfService
+		if slotSnapshot.Car == nil {
+			continue
+		}
+		if slotSnapshot.TicketId == "" || slotSnapshot.ParkedAt == nil {
+			return nil, Exceptions.ErrInvalidSnapshot.WithLot(snapshot.Id).WithRegistration(slotSnapshot.Car.RegistrationNumber)
+		}
+		slot.occupy(*slotSnapshot.Car, &Ticket{ticketID: slotSnapshot.TicketId}, *slotSnapshot.ParkedAt)
+	}
+	if err := lot.SetOccupancyThresholds(snapshot.Thresholds...); err != nil {
+		return nil, err
+	}
+	if snapshot.Emergency != nil {
+		emergency := &Emergency{
+			ParkingLotId: lot.ParkingLotId,
+			Reason:       snapshot.Emergency.Reason,
+			DeclaredAt:   snapshot.Emergency.DeclaredAt,
+			vehicles:     []*EvacuatingVehicle{},
+		}
+		for _, vehicle := range snapshot.Emergency.Vehicles {
+			evacuating := vehicle
+			emergency.vehicles = append(emergency.vehicles, &evacuating)
+		}
+		lot.emergency = emergency
+	}
+	lot.isFull = lot.IsFull()
+	return lot, nil
+}
+
+func (owner *Owner) restoreAttendent(attendent *Attendent, snapshot AttendentSnapshot, lots map[int]*ParkingLot) error {
+	attendent.Id = snapshot.Id
+	attendent.Principal = snapshot.Principal
+	attendent.DistanceToEntrance = snapshot.DistanceToEntrance
+	if newStrategy, ok := nextLotStrategies[snapshot.NextLotStrategy]; ok {
+		attendent.NextLotStrategy = newStrategy()
+	}
+	if snapshot.Employed {
+		attendent.AssignedOwner = owner
+	}
+	for _, parkingLotId := range snapshot.ParkingLotIds {
+		lot, ok := lots[parkingLotId]
+		if !ok {
+			return Exceptions.ErrInvalidSnapshot.WithLot(parkingLotId)
+		}
+		attendent.AssignedParkingLots = append(attendent.AssignedParkingLots, lot)
+	}
+	for _, ticketId := range snapshot.TicketIds {
+		attended := findAttendedCar(ticketId, lots)
+		if attended == nil {
+			return Exceptions.ErrInvalidSnapshot.WithTicket(ticketId)
+		}
+		attendent.track(attended, newParkingTransaction())
+	}
+	for _, contractSnapshot := range snapshot.Contracts {
+		contract := &Contract{
+			Owner:       owner,
+			ParkingLots: []*ParkingLot{},
+			Start:       contractSnapshot.Start,
+			End:         contractSnapshot.End,
+			FeePerPark:  contractSnapshot.FeePerPark,
+		}
+		for _, parkingLotId := range contractSnapshot.ParkingLotIds {
+			if lot, ok := lots[parkingLotId]; ok {
+				contract.ParkingLots = append(contract.ParkingLots, lot)
+			}
+		}
+		attendent.Contracts = append(attendent.Contracts, contract)
+	}
+	return nil
+}
+
+func findAttendedCar(ticketId string, lots map[int]*ParkingLot) *attendedCar {
+	for _, lot := range lots {
+		if slot := lot.slotHolding(ticketId); slot != nil {
+			return &attendedCar{car: slot.car, ticket: slot.ticket, lot: lot}
+		}
+	}
+	return nil
+}
+
+func (owner *Owner) restoreSubscription(snapshot SubscriptionSnapshot, subscribers map[string]Notifiable, lots map[int]*ParkingLot) error {
+	notifiable := subscribers[snapshot.Subscriber]
+	if snapshot.Subscriber == OwnerSubscriber {
+		notifiable = owner
+	}
+	if notifiable == nil {
+		return Exceptions.ErrInvalidSnapshot
+	}
+	if snapshot.AllLots {
+		owner.SubscribeAll(notifiable, snapshot.Filter)
+		return nil
+	}
+	if len(snapshot.Thresholds) > 0 {
+		for _, parkingLotId := range snapshot.ParkingLotIds {
+			if lot, ok := lots[parkingLotId]; ok {
+				if _, err := lot.SubscribeThresholds(notifiable, snapshot.Thresholds...); err != nil {
+					return Exceptions.ErrInvalidSnapshot.Wrap(err)
+				}
+			}
+		}
+		return nil
+	}
+	subscription := &Subscription{notifiable: notifiable, filter: snapshot.Filter, active: true}
+	for _, parkingLotId := range snapshot.ParkingLotIds {
+		if lot, ok := lots[parkingLotId]; ok {
+			subscription.attach(lot)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,123 @@
+package Implementations
+
+import (
+	"ParkingLot_go/Enums"
+	"sync"
+	"time"
+)
+
+type ChangeType string
+
+const (
+	ChangeOwnerRegistered      ChangeType = "OWNER_REGISTERED"
+	ChangeLotCreated           ChangeType = "LOT_CREATED"
+	ChangeSlotAdded            ChangeType = "SLOT_ADDED"
+	ChangeSlotTypeSet          ChangeType = "SLOT_TYPE_SET"
+	ChangeSlotOutOfService     ChangeType = "SLOT_OUT_OF_SERVICE"
+	ChangeSlotInService        ChangeType = "SLOT_IN_SERVICE"
+	ChangeCarParked            ChangeType = "CAR_PARKED"
+	ChangeCarUnparked          ChangeType = "CAR_UNPARKED"
+	ChangeTicketVoided         ChangeType = "TICKET_VOIDED"
+	ChangeAttendentHired       ChangeType = "ATTENDENT_HIRED"
+	ChangeAttendentFired       ChangeType = "ATTENDENT_FIRED"
+	ChangeAttendentAssigned    ChangeType = "ATTENDENT_ASSIGNED"
+	ChangeAttendentReleased    ChangeType = "ATTENDENT_RELEASED"
+	ChangeCarAttended          ChangeType = "CAR_ATTENDED"
+	ChangeOwnershipTransferred ChangeType = "OWNERSHIP_TRANSFERRED"
+)
+
+// StateChange is one entry of the state log. Only the fields that matter
+// for its type are set; Sequence is assigned when the change is appended.
+type StateChange struct {
+	Sequence       int
+	Type           ChangeType
+	At             time.Time
+	OwnerId        string
+	AttendentId    string
+	ParkingLotId   int
+	ParkingLotName string
+	TotalSlots     int
+	SlotNumber     int
+	SlotType       Enums.SlotType
+	Vehicle        Car
+	TicketId       string
+	ToOwnerId      string
+}
+
+// Projection is kept up to date with every change appended to a log it is
+// registered with.
+type Projection interface {
+	Apply(change StateChange)
+}
+
+// StateLog is an append-only record of every change made to the owners,
+// lots and attendents writing to it. Replaying it rebuilds their state.
+type StateLog struct {
+	mu          sync.Mutex
+	changes     []StateChange
+	projections []Projection
+}
+
+func StateLogConstruct() *StateLog {
+	return &StateLog{changes: []StateChange{}}
+}
+
+func (log *StateLog) Append(change StateChange) StateChange {
+	log.mu.Lock()
+	defer log.mu.Unlock()
+	change.Sequence = len(log.changes) + 1
+	log.changes = append(log.changes, change)
+	for _, projection := range log.projections {
+		projection.Apply(change)
+	}
+	return change
+}
+
+func (log *StateLog) Changes() []StateChange {
+	log.mu.Lock()
+	defer log.mu.Unlock()
+	return append([]StateChange{}, log.changes...)
+}
+
+// ChangesUntil returns the log up to the first change made after the given
+// time. Cutting the log there, rather than filtering by time, never keeps a
+// change while dropping one it depends on, such as a car logged with its
+// original parking time right after its lot.
+func (log *StateLog) ChangesUntil(at time.Time) []StateChange {
+	log.mu.Lock()
+	defer log.mu.Unlock()
+	for i, change := range log.changes {
+		if change.At.After(at) {
+			return append([]StateChange{}, log.changes[:i]...)
+		}
+	}
+	return append([]StateChange{}, log.changes...)
+}
+
+// Project feeds the changes logged so far to the projection and keeps it
+// current from then on.
+func (log *StateLog) Project(projection Projection) {
+	log.mu.Lock()
+	defer log.mu.Unlock()
+	for _, change := range log.changes {
+		projection.Apply(change)
+	}
+	log.projections = append(log.projections, projection)
+}
+
+// State rebuilds the current state from the whole log.
+func (log *StateLog) State() (*ReplayedState, error) {
+	return Replay(log.Changes())
+}
+
+// StateAt rebuilds the state as it was at the given time.
+func (log *StateLog) StateAt(at time.Time) (*ReplayedState, error) {
+	return Replay(log.ChangesUntil(at))
+}
+
+// record appends the change when there is a log to write to.
+func (log *StateLog) record(change StateChange) {
+	if log != nil {
+		log.Append(change)
+	}
+}
@@ -0,0 +1,167 @@
+package Implementations
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+// ProjectedOccupancy is a lot's occupancy as the state log tells it.
+type ProjectedOccupancy struct {
+	ParkingLotId int
+	Capacity     int
+	Occupied     int
+}
+
+type projectedLot struct {
+	inService map[int]bool
+	tickets   map[string]bool
+}
+
+// OccupancyProjection follows how many in-service slots and parked cars each
+// lot has.
+type OccupancyProjection struct {
+	mu   sync.Mutex
+	lots map[int]*projectedLot
+}
+
+func OccupancyProjectionConstruct() *OccupancyProjection {
+	return &OccupancyProjection{lots: map[int]*projectedLot{}}
+}
+
+func (projection *OccupancyProjection) Apply(change StateChange) {
+	projection.mu.Lock()
+	defer projection.mu.Unlock()
+	if projection.lots == nil {
+		projection.lots = map[int]*projectedLot{}
+	}
+	lot, ok := projection.lots[change.ParkingLotId]
+	if change.Type == ChangeLotCreated {
+		lot = &projectedLot{inService: map[int]bool{}, tickets: map[string]bool{}}
+		for slotNumber := 1; slotNumber <= change.TotalSlots; slotNumber++ {
+			lot.inService[slotNumber] = true
+		}
+		projection.lots[change.ParkingLotId] = lot
+		return
+	}
+	if !ok {
+		return
+	}
+	switch change.Type {
+	case ChangeSlotAdded, ChangeSlotInService:
+		lot.inService[change.SlotNumber] = true
+	case ChangeSlotOutOfService:
+		lot.inService[change.SlotNumber] = false
+	case ChangeCarParked:
+		lot.tickets[change.TicketId] = true
+	case ChangeCarUnparked, ChangeTicketVoided:
+		delete(lot.tickets, change.TicketId)
+	}
+}
+
+func (projection *OccupancyProjection) Lot(parkingLotId int) ProjectedOccupancy {
+	projection.mu.Lock()
+	defer projection.mu.Unlock()
+	return projection.occupancyOf(parkingLotId)
+}
+
+// Lots returns every lot seen so far in ID order.
+func (projection *OccupancyProjection) Lots() []ProjectedOccupancy {
+	projection.mu.Lock()
+	defer projection.mu.Unlock()
+	lots := make([]ProjectedOccupancy, 0, len(projection.lots))
+	for parkingLotId := range projection.lots {
+		lots = append(lots, projection.occupancyOf(parkingLotId))
+	}
+	sort.Slice(lots, func(i, j int) bool { return lots[i].ParkingLotId < lots[j].ParkingLotId })
+	return lots
+}
+
+func (projection *OccupancyProjection) occupancyOf(parkingLotId int) ProjectedOccupancy {
+	occupancy := ProjectedOccupancy{ParkingLotId: parkingLotId}
+	lot, ok := projection.lots[parkingLotId]
+	if !ok {
+		return occupancy
+	}
+	for _, inService := range lot.inService {
+		if inService {
+			occupancy.Capacity++
+		}
+	}
+	occupancy.Occupied = len(lot.tickets)
+	return occupancy
+}
+
+// Visit is one stay of a car in a lot. LeftAt is zero while the car is still
+// parked.
+type Visit struct {
+	TicketId     string
+	Vehicle      Car
+	ParkingLotId int
+	SlotNumber   int
+	ParkedAt     time.Time
+	LeftAt       time.Time
+	Voided       bool
+}
+
+// HistoryProjection keeps every visit recorded in the log, in the order the
+// cars were parked.
+type HistoryProjection struct {
+	mu       sync.Mutex
+	visits   []*Visit
+	byTicket map[string]*Visit
+}
+
+func HistoryProjectionConstruct() *HistoryProjection {
+	return &HistoryProjection{byTicket: map[string]*Visit{}}
+}
+
+func (projection *HistoryProjection) Apply(change StateChange) {
+	projection.mu.Lock()
+	defer projection.mu.Unlock()
+	if projection.byTicket == nil {
+		projection.byTicket = map[string]*Visit{}
+	}
+	switch change.Type {
+	case ChangeCarParked:
+		visit := &Visit{
+			TicketId:     change.TicketId,
+			Vehicle:      change.Vehicle,
+			ParkingLotId: change.ParkingLotId,
+			SlotNumber:   change.SlotNumber,
+			ParkedAt:     change.At,
+		}
+		projection.visits = append(projection.visits, visit)
+		projection.byTicket[change.TicketId] = visit
+	case ChangeCarUnparked, ChangeTicketVoided:
+		if visit, ok := projection.byTicket[change.TicketId]; ok {
+			visit.LeftAt = change.At
+			visit.Voided = change.Type == ChangeTicketVoided
+			delete(projection.byTicket, change.TicketId)
+		}
+	}
+}
+
+func (projection *HistoryProjection) VisitsOf(registrationNumber string) []Visit {
+	return projection.visitsWhere(func(visit *Visit) bool {
+		return visit.Vehicle.HasRegistrationNumber(registrationNumber)
+	})
+}
+
+func (projection *HistoryProjection) VisitsTo(parkingLotId int) []Visit {
+	return projection.visitsWhere(func(visit *Visit) bool {
+		return visit.ParkingLotId == parkingLotId
+	})
+}
+
+func (projection *HistoryProjection) visitsWhere(matches func(visit *Visit) bool) []Visit {
+	projection.mu.Lock()
+	defer projection.mu.Unlock()
+	visits := []Visit{}
+	for _, visit := range projection.visits {
+		if matches(visit) {
+			visits = append(visits, *visit)
+		}
+	}
+	return visits
+}
@@ -0,0 +1,257 @@
+package Implementations
+
+import (
+	"ParkingLot_go/Enums"
+	"ParkingLot_go/Exceptions"
+	"sort"
+)
+
+// ReplayedState is what a state log rebuilds: owners and attendents by ID and
+// lots by lot ID, wired together as they were. Contracts, rosters, metrics
+// and subscriptions other than each owner's own are not part of the log.
+type ReplayedState struct {
+	Owners      map[string]*Owner
+	ParkingLots map[int]*ParkingLot
+	Attendents  map[string]*Attendent
+}
+
+// Replay applies the changes in order to an empty world. It fails on the
+// first change that does not fit the state built so far.
+func Replay(changes []StateChange) (*ReplayedState, error) {
+	state := &ReplayedState{
+		Owners:      map[string]*Owner{},
+		ParkingLots: map[int]*ParkingLot{},
+		Attendents:  map[string]*Attendent{},
+	}
+	for _, change := range changes {
+		if err := state.apply(change); err != nil {
+			return nil, err
+		}
+	}
+	for _, lot := range state.ParkingLots {
+		lot.isFull = lot.IsFull()
+	}
+	return state, nil
+}
+
+func (state *ReplayedState) owner(ownerId string) *Owner {
+	owner, ok := state.Owners[ownerId]
+	if !ok {
+		owner = OwnerConstruct()
+		owner.Id = ownerId
+		state.Owners[ownerId] = owner
+	}
+	return owner
+}
+
+func (state *ReplayedState) attendent(attendentId string) *Attendent {
+	attendent, ok := state.Attendents[attendentId]
+	if !ok {
+		attendent = AttendentConstructDefault()
+		attendent.Id = attendentId
+		state.Attendents[attendentId] = attendent
+	}
+	return attendent
+}
+
+// SortedParkingLots returns the lots in ID order.
+func (state *ReplayedState) SortedParkingLots() []*ParkingLot {
+	lots := make([]*ParkingLot, 0, len(state.ParkingLots))
+	for _, lot := range state.ParkingLots {
+		lots = append(lots, lot)
+	}
+	sort.Slice(lots, func(i, j int) bool { return lots[i].ParkingLotId < lots[j].ParkingLotId })
+	return lots
+}
+
+func (state *ReplayedState) apply(change StateChange) error {
+	invalid := Exceptions.ErrInvalidStateLog.WithLot(change.ParkingLotId).WithTicket(change.TicketId)
+	if change.Type == ChangeOwnerRegistered {
+		owner := state.owner(change.OwnerId)
+		owner.Attendent.Id = change.AttendentId
+		state.Attendents[change.AttendentId] = &owner.Attendent
+		return nil
+	}
+	if change.Type == ChangeLotCreated {
+		if _, exists := state.ParkingLots[change.ParkingLotId]; exists {
+			return invalid
+		}
+		owner := state.owner(change.OwnerId)
+		lot, err := ParkingLotConstructChecked(change.TotalSlots, owner)
+		if err != nil {
+			return invalid.Wrap(err)
+		}
+		lot.ParkingLotId = change.ParkingLotId
+		lot.RegisterNotifiable(owner)
+		owner.OwnerParkingLots = append(owner.OwnerParkingLots, lot)
+		state.ParkingLots[lot.ParkingLotId] = lot
+	}
+	if change.Type == ChangeAttendentHired || change.Type == ChangeAttendentFired {
+		owner, attendent := state.owner(change.OwnerId), state.attendent(change.AttendentId)
+		if change.Type == ChangeAttendentHired && !owner.employs(attendent) {
+			owner.Attendents = append(owner.Attendents, attendent)
+			if attendent.AssignedOwner == nil {
+				attendent.AssignedOwner = owner
+			}
+		}
+		if change.Type == ChangeAttendentFired {
+			for i, hired := range owner.Attendents {
+				if hired == attendent {
+					owner.Attendents = append(owner.Attendents[:i], owner.Attendents[i+1:]...)
+					break
+				}
+			}
+			if attendent.AssignedOwner == owner {
+				attendent.AssignedOwner = nil
+			}
+		}
+		return nil
+	}
+
+	lot, ok := state.ParkingLots[change.ParkingLotId]
+	if !ok {
+		return invalid
+	}
+	if change.ParkingLotName != "" {
+		lot.Name = change.ParkingLotName
+	}
+	switch change.Type {
+	case ChangeSlotAdded:
+		if change.SlotNumber != len(lot.slots)+1 {
+			return invalid
+		}
+		slot := SlotConstruct()
+		slot.slotType = change.SlotType
+		lot.slots = append(lot.slots, slot)
+		lot.totalSlots++
+	case ChangeSlotTypeSet, ChangeSlotOutOfService, ChangeSlotInService:
+		slot, err := lot.slotAt(change.SlotNumber)
+		if err != nil {
+			return invalid
+		}
+		slot.slotType = change.SlotType
+		if change.Type != ChangeSlotTypeSet {
+			slot.outOfService = change.Type == ChangeSlotOutOfService
+		}
+	case ChangeCarParked:
+		slot, err := lot.slotAt(change.SlotNumber)
+		if err != nil || !slot.IsFree() {
+			return invalid
+		}
+		slot.occupy(change.Vehicle, &Ticket{ticketID: change.TicketId}, change.At)
+	case ChangeCarUnparked, ChangeTicketVoided:
+		slot, err := lot.slotAt(change.SlotNumber)
+		if err != nil || slot.IsFree() || slot.ticket.GetTicketId() != change.TicketId {
+			return invalid
+		}
+		slot.release()
+		state.dropTicket(change.TicketId)
+	case ChangeAttendentAssigned:
+		attendent := state.attendent(change.AttendentId)
+		if !contains(attendent.AssignedParkingLots, lot) {
+			attendent.AssignedParkingLots = append(attendent.AssignedParkingLots, lot)
+		}
+		if attendent.AssignedOwner == nil {
+			attendent.AssignedOwner = lot.Owner
+		}
+	case ChangeAttendentReleased:
+		state.attendent(change.AttendentId).releaseLot(lot, newParkingTransaction())
+	case ChangeCarAttended:
+		slot := lot.slotHolding(change.TicketId)
+		if slot == nil {
+			return invalid
+		}
+		state.dropTicket(change.TicketId)
+		attended := &attendedCar{car: slot.car, ticket: slot.ticket, lot: lot}
+		state.attendent(change.AttendentId).track(attended, newParkingTransaction())
+	case ChangeOwnershipTransferred:
+		from, to := state.owner(change.OwnerId), state.owner(change.ToOwnerId)
+		for i, owned := range from.OwnerParkingLots {
+			if owned == lot {
+				from.OwnerParkingLots = append(from.OwnerParkingLots[:i], from.OwnerParkingLots[i+1:]...)
+				break
+			}
+		}
+		to.OwnerParkingLots = append(to.OwnerParkingLots, lot)
+		lot.changeOwner(&OwnershipTransfer{
+			ParkingLotId:    lot.ParkingLotId,
+			From:            from,
+			To:              to,
+			At:              change.At,
+			ActiveTicketIds: lot.activeTicketIds(),
+		})
+	}
+	return nil
+}
+
+// dropTicket takes the ticket away from whichever attendent holds it.
+func (state *ReplayedState) dropTicket(ticketId string) {
+	for _, attendent := range state.Attendents {
+		if attended, ok := attendent.tickets[ticketId]; ok {
+			attendent.untrack(attended, newParkingTransaction())
+		}
+	}
+}
+
+func (parkinglot *ParkingLot) slotHolding(ticketId string) *Slot {
+	for _, slot := range parkinglot.slots {
+		if slot.HoldsTicket(&Ticket{ticketID: ticketId}) {
+			return slot
+		}
+	}
+	return nil
+}
+
+func recordOwner(log *StateLog, owner *Owner) {
+	log.record(StateChange{
+		Type:        ChangeOwnerRegistered,
+		At:          owner.now(),
+		OwnerId:     owner.identity(),
+		AttendentId: owner.Attendent.identity(),
+	})
+}
+
+// recordLot logs the lot as it stands: created with its slots, then any slot
+// that differs from a fresh one and every car parked in it.
+func recordLot(log *StateLog, parkingLot *ParkingLot) {
+	if log == nil {
+		return
+	}
+	created := parkingLot.lotChange(ChangeLotCreated)
+	created.TotalSlots = len(parkingLot.slots)
+	log.record(created)
+	for _, slot := range parkingLot.slots {
+		if slot.Type() != Enums.STANDARD {
+			log.record(parkingLot.slotChange(ChangeSlotTypeSet, slot))
+		}
+		if !slot.IsInService() {
+			log.record(parkingLot.slotChange(ChangeSlotOutOfService, slot))
+		}
+		if !slot.IsFree() {
+			log.record(parkingLot.slotChange(ChangeCarParked, slot))
+		}
+	}
+}
+
+// recordAttendent logs the attendent's assignment to each of the given lots
+// it works, and the cars it holds there.
+func recordAttendent(log *StateLog, attendent *Attendent, parkingLots []*ParkingLot) {
+	if log == nil {
+		return
+	}
+	for _, lot := range attendent.AssignedParkingLots {
+		if contains(parkingLots, lot) {
+			log.record(attendent.lotChange(ChangeAttendentAssigned, lot))
+		}
+	}
+	ticketIds := make([]string, 0, len(attendent.tickets))
+	for ticketId := range attendent.tickets {
+		ticketIds = append(ticketIds, ticketId)
+	}
+	sort.Strings(ticketIds)
+	for _, ticketId := range ticketIds {
+		if attended := attendent.tickets[ticketId]; contains(parkingLots, attended.lot) {
+			log.record(attendent.attendedChange(attended))
+		}
+	}
+}
@@ -0,0 +1,246 @@
+package Implementations
+
+import (
+	"ParkingLot_go/Enums"
+	"reflect"
+	"slices"
+)
+
+// EventFilter narrows the events a subscription receives. Empty fields match
+// everything; vehicle fields only match events that carry a vehicle.
+type EventFilter struct {
+	EventTypes          []EventType
+	ParkingLotIds       []int
+	MinLevel            EventLevel
+	Colors              []Enums.Color
+	VehicleTypes        []Enums.VehicleType
+	RegistrationNumbers []string
+}
+
+func (filter EventFilter) Matches(event Event) bool {
+	if len(filter.EventTypes) > 0 && !slices.Contains(filter.EventTypes, event.Type) {
+		return false
+	}
+	if len(filter.ParkingLotIds) > 0 && !slices.Contains(filter.ParkingLotIds, event.ParkingLotId) {
+		return false
+	}
+	if event.Level() < filter.MinLevel {
+		return false
+	}
+	if len(filter.Colors) == 0 && len(filter.VehicleTypes) == 0 && len(filter.RegistrationNumbers) == 0 {
+		return true
+	}
+	if event.Vehicle == nil {
+		return false
+	}
+	if len(filter.Colors) > 0 && !slices.Contains(filter.Colors, event.Vehicle.Color) {
+		return false
+	}
+	if len(filter.VehicleTypes) > 0 && !slices.Contains(filter.VehicleTypes, event.Vehicle.TypeOfVehicle()) {
+		return false
+	}
+	if len(filter.RegistrationNumbers) > 0 && !slices.Contains(filter.RegistrationNumbers, event.Vehicle.RegistrationNumber) {
+		return false
+	}
+	return true
+}
+
+// Subscription is the handle returned by every subscribe call. An owner-wide
+// subscription follows the owner's lots as they are created or sold. A
+// threshold subscription watches the thresholds it was made with at one lot.
+type Subscription struct {
+	notifiable Notifiable
+	filter     EventFilter
+	lots       []*ParkingLot
+	owner      *Owner
+	watching   *ParkingLot
+	active     bool
+}
+
+func (subscription *Subscription) Filter() EventFilter {
+	return subscription.filter
+}
+
+func (subscription *Subscription) IsActive() bool {
+	return subscription.active
+}
+
+// Unsubscribe stops all further deliveries. Calling it again does nothing.
+func (subscription *Subscription) Unsubscribe() {
+	if !subscription.active {
+		return
+	}
+	subscription.active = false
+	for _, lot := range append([]*ParkingLot{}, subscription.lots...) {
+		subscription.detach(lot)
+	}
+	subscription.unwatch()
+	if subscription.owner != nil {
+		subscription.owner.subscriptions = slices.DeleteFunc(subscription.owner.subscriptions, func(held *Subscription) bool {
+			return held == subscription
+		})
+	}
+}
+
+func (subscription *Subscription) attach(parkingLot *ParkingLot) {
+	if contains(subscription.lots, parkingLot) {
+		return
+	}
+	subscription.lots = append(subscription.lots, parkingLot)
+	parkingLot.subscriptions = append(parkingLot.subscriptions, subscription)
+}
+
+func (subscription *Subscription) detach(parkingLot *ParkingLot) {
+	subscription.lots = slices.DeleteFunc(subscription.lots, func(lot *ParkingLot) bool {
+		return lot == parkingLot
+	})
+	parkingLot.subscriptions = slices.DeleteFunc(parkingLot.subscriptions, func(held *Subscription) bool {
+		return held == subscription
+	})
+}
+
+// unwatch drops the subscription's thresholds from the lot it watches.
+func (subscription *Subscription) unwatch() {
+	if subscription.watching == nil {
+		return
+	}
+	subscription.watching.thresholds = slices.DeleteFunc(subscription.watching.thresholds, func(watch *thresholdWatch) bool {
+		return watch.subscription == subscription
+	})
+	subscription.watching = nil
+}
+
+// thresholdSubscriptions are the lot's threshold subscriptions, each once.
+func (parkinglot *ParkingLot) thresholdSubscriptions() []*Subscription {
+	subscriptions := []*Subscription{}
+	for _, watch := range parkinglot.thresholds {
+		if watch.subscription != nil && !slices.Contains(subscriptions, watch.subscription) {
+			subscriptions = append(subscriptions, watch.subscription)
+		}
+	}
+	return subscriptions
+}
+
+// thresholds are the thresholds the subscription watches.
+func (subscription *Subscription) thresholds() []OccupancyThreshold {
+	thresholds := []OccupancyThreshold{}
+	if subscription.watching == nil {
+		return thresholds
+	}
+	for _, watch := range subscription.watching.thresholds {
+		if watch.subscription == subscription {
+			thresholds = append(thresholds, watch.threshold)
+		}
+	}
+	return thresholds
+}
+
+func (subscription *Subscription) duplicates(notifiable Notifiable, filter EventFilter) bool {
+	return subscription.owner == nil && sameNotifiable(subscription.notifiable, notifiable) && reflect.DeepEqual(subscription.filter, filter)
+}
+
+// SubscribeWithFilter delivers matching events to the notifiable. The same
+// notifiable subscribing again with the same filter gets the existing
+// subscription back.
+func (parkinglot *ParkingLot) SubscribeWithFilter(notifiable Notifiable, filter EventFilter) *Subscription {
+	for _, subscription := range parkinglot.subscriptions {
+		if subscription.duplicates(notifiable, filter) {
+			return subscription
+		}
+	}
+	subscription := &Subscription{notifiable: notifiable, filter: filter, active: true}
+	subscription.attach(parkinglot)
+	return subscription
+}
+
+// Subscribe delivers the given event types to the notifiable, or every event
+// when no types are given.
+func (parkinglot *ParkingLot) Subscribe(notifiable Notifiable, eventTypes ...EventType) *Subscription {
+	return parkinglot.SubscribeWithFilter(notifiable, EventFilter{EventTypes: eventTypes})
+}
+
+// defaultEventTypes are what owners and registered notifiables hear about:
+// the lot filling up, freeing up, and cars overstaying.
+var defaultEventTypes = []EventType{LotFull, LotAvailable, VehicleOverstayed}
+
+// registeredEventTypes is implemented by notifiables that cannot work on the
+// default events alone, such as a policeman who needs every arrival to check
+// against its watchlist.
+type registeredEventTypes interface {
+	registeredEventTypes() []EventType
+}
+
+// RegisterNotifiable subscribes the notifiable to the default events, plus
+// any others it needs to do its job.
+func (parkinglot *ParkingLot) RegisterNotifiable(notifiable Notifiable) *Subscription {
+	if registered, ok := notifiable.(registeredEventTypes); ok {
+		return parkinglot.Subscribe(notifiable, registered.registeredEventTypes()...)
+	}
+	return parkinglot.Subscribe(notifiable, defaultEventTypes...)
+}
+
+// publish delivers the event at most once to each subscriber, however many of
+// its subscriptions match.
+func (parkinglot *ParkingLot) publish(event Event) {
+	parkinglot.deliver(event, false)
+}
+
+// broadcast delivers the event to every subscriber whatever its filter, for
+// emergencies nobody may miss.
+func (parkinglot *ParkingLot) broadcast(event Event) {
+	parkinglot.deliver(event, true)
+}
+
+func (parkinglot *ParkingLot) deliver(event Event, toEveryone bool) {
+	event.OccupancyPercent = parkinglot.PercentFull()
+	notified := []Notifiable{}
+	for _, subscription := range append([]*Subscription{}, parkinglot.subscriptions...) {
+		if !toEveryone && !subscription.filter.Matches(event) {
+			continue
+		}
+		if slices.ContainsFunc(notified, func(done Notifiable) bool { return sameNotifiable(done, subscription.notifiable) }) {
+			continue
+		}
+		notified = append(notified, subscription.notifiable)
+		subscription.notifiable.Notify(event)
+	}
+}
+
+// SubscribeAll subscribes the notifiable to every lot the owner has now or
+// gets later, until it is unsubscribed.
+func (owner *Owner) SubscribeAll(notifiable Notifiable, filter EventFilter) *Subscription {
+	subscription := &Subscription{notifiable: notifiable, filter: filter, owner: owner, active: true}
+	for _, parkingLot := range owner.OwnerParkingLots {
+		subscription.attach(parkingLot)
+	}
+	owner.subscriptions = append(owner.subscriptions, subscription)
+	return subscription
+}
+
+// moveSubscriptions hands a sold lot from one owner's subscriptions to the
+// other's. The seller's own subscription passes to the buyer with its filter;
+// the seller's threshold subscriptions end.
+func (parkinglot *ParkingLot) moveSubscriptions(from *Owner, to *Owner) {
+	for _, subscription := range parkinglot.thresholdSubscriptions() {
+		if sameNotifiable(subscription.notifiable, from) {
+			subscription.unwatch()
+			subscription.active = false
+		}
+	}
+	filter := EventFilter{EventTypes: defaultEventTypes}
+	for _, subscription := range append([]*Subscription{}, parkinglot.subscriptions...) {
+		if subscription.owner == from {
+			subscription.detach(parkinglot)
+			continue
+		}
+		if subscription.owner == nil && (sameNotifiable(subscription.notifiable, from) || sameNotifiable(subscription.notifiable, to)) {
+			filter = subscription.filter
+			subscription.detach(parkinglot)
+			subscription.active = false
+		}
+	}
+	parkinglot.SubscribeWithFilter(to, filter)
+	for _, subscription := range to.subscriptions {
+		subscription.attach(parkinglot)
+	}
+}
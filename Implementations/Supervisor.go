@@ -0,0 +1,155 @@
+package Implementations
+
+import (
+	"ParkingLot_go/Exceptions"
+	"sort"
+)
+
+// Supervisor attends lots through a team of attendables, which may be
+// attendents or other supervisors.
+type Supervisor struct {
+	Team      []Attendable
+	Principal *Principal
+}
+
+func SupervisorConstruct(team ...Attendable) *Supervisor {
+	return &Supervisor{
+		Team: append([]Attendable{}, team...),
+	}
+}
+
+// SupervisorConstructAs builds a supervisor acting as the principal, whose
+// supervisor role then counts at the lots the team attends.
+func SupervisorConstructAs(principal *Principal, team ...Attendable) *Supervisor {
+	supervisor := SupervisorConstruct(team...)
+	supervisor.Principal = principal
+	principal.supervisor = supervisor
+	return supervisor
+}
+
+func (supervisor *Supervisor) AddToTeam(member Attendable) error {
+	if supervisor.reaches(member) {
+		return Exceptions.ErrSupervisorCycle
+	}
+	if nested, ok := member.(*Supervisor); ok && nested.reaches(supervisor) {
+		return Exceptions.ErrSupervisorCycle
+	}
+	supervisor.Team = append(supervisor.Team, member)
+	return nil
+}
+
+// reaches reports whether the member is this supervisor or already sits
+// anywhere below it.
+func (supervisor *Supervisor) reaches(member Attendable) bool {
+	if member == Attendable(supervisor) {
+		return true
+	}
+	for _, teamMember := range supervisor.Team {
+		if teamMember == member {
+			return true
+		}
+		if nested, ok := teamMember.(*Supervisor); ok && nested.reaches(member) {
+			return true
+		}
+	}
+	return false
+}
+
+// Assign hands the lot to the team member currently attending the fewest lots.
+func (supervisor *Supervisor) Assign(parkingLot *ParkingLot, owner *Owner) error {
+	if contains(supervisor.AssignedLots(), parkingLot) {
+		return Exceptions.ErrParkingLotAlreadyAssigned.WithLot(parkingLot.GetParkingLotId())
+	}
+	members := supervisor.sortedTeam(func(member Attendable) int { return len(member.AssignedLots()) })
+	var err error = Exceptions.ErrNoAttendentAvailable.WithLot(parkingLot.GetParkingLotId())
+	for _, member := range members {
+		if err = member.Assign(parkingLot, owner); err == nil {
+			return nil
+		}
+	}
+	return err
+}
+
+// Park routes the car to the available team member with the fewest parked cars.
+func (supervisor *Supervisor) Park(car *Car) (*Ticket, error) {
+	if err := supervisor.CheckIfCarIsAlreadyParked(car); err != nil {
+		return nil, err
+	}
+	members := supervisor.sortedTeam(func(member Attendable) int { return len(member.ParkedCarsView()) })
+	var err error = Exceptions.ErrNoAttendentAvailable.WithRegistration(car.RegistrationNumber)
+	for _, member := range members {
+		if !member.IsAvailable() {
+			continue
+		}
+		ticket, parkErr := member.Park(car)
+		if parkErr == nil {
+			return ticket, nil
+		}
+		err = parkErr
+	}
+	return nil, err
+}
+
+func (supervisor *Supervisor) CheckIfCarIsAlreadyParked(car *Car) error {
+	for _, member := range supervisor.Team {
+		if err := member.CheckIfCarIsAlreadyParked(car); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (supervisor *Supervisor) Unpark(ticket *Ticket) (*Car, error) {
+	for _, member := range supervisor.Team {
+		if member.HoldsTicket(ticket) {
+			return member.Unpark(ticket)
+		}
+	}
+	return nil, Exceptions.ErrCarNotFound.WithTicket(ticketIdOf(ticket))
+}
+
+func (supervisor *Supervisor) HoldsTicket(ticket *Ticket) bool {
+	for _, member := range supervisor.Team {
+		if member.HoldsTicket(ticket) {
+			return true
+		}
+	}
+	return false
+}
+
+func (supervisor *Supervisor) IsAvailable() bool {
+	for _, member := range supervisor.Team {
+		if member.IsAvailable() {
+			return true
+		}
+	}
+	return false
+}
+
+func (supervisor *Supervisor) AssignedLots() []*ParkingLot {
+	lots := []*ParkingLot{}
+	for _, member := range supervisor.Team {
+		for _, lot := range member.AssignedLots() {
+			if !contains(lots, lot) {
+				lots = append(lots, lot)
+			}
+		}
+	}
+	return lots
+}
+
+func (supervisor *Supervisor) ParkedCarsView() []*Car {
+	cars := []*Car{}
+	for _, member := range supervisor.Team {
+		cars = append(cars, member.ParkedCarsView()...)
+	}
+	return cars
+}
+
+func (supervisor *Supervisor) sortedTeam(load func(member Attendable) int) []Attendable {
+	members := append([]Attendable{}, supervisor.Team...)
+	sort.SliceStable(members, func(i, j int) bool {
+		return load(members[i]) < load(members[j])
+	})
+	return members
+}
@@ -15,5 +15,16 @@ func TicketConstruct() *Ticket {
 }
 
 func (t *Ticket) Equals(other *Ticket) bool {
-	return t.ticketID == other.ticketID
+	return other != nil && t.ticketID == other.ticketID
+}
+
+func (t *Ticket) GetTicketId() string {
+	return t.ticketID
+}
+
+func ticketIdOf(ticket *Ticket) string {
+	if ticket == nil {
+		return ""
+	}
+	return ticket.GetTicketId()
 }
@@ -0,0 +1,42 @@
+package Implementations
+
+// parkingTransaction groups the steps of a park or unpark that touch more
+// than one object. Undo steps run in reverse order on rollback; side effects
+// such as notifications are held back until commit.
+type parkingTransaction struct {
+	rollbacks []func()
+	effects   []func()
+	done      bool
+}
+
+func newParkingTransaction() *parkingTransaction {
+	return &parkingTransaction{}
+}
+
+func (tx *parkingTransaction) onRollback(undo func()) {
+	tx.rollbacks = append(tx.rollbacks, undo)
+}
+
+func (tx *parkingTransaction) afterCommit(effect func()) {
+	tx.effects = append(tx.effects, effect)
+}
+
+func (tx *parkingTransaction) rollback() {
+	if tx.done {
+		return
+	}
+	tx.done = true
+	for i := len(tx.rollbacks) - 1; i >= 0; i-- {
+		tx.rollbacks[i]()
+	}
+}
+
+func (tx *parkingTransaction) commit() {
+	if tx.done {
+		return
+	}
+	tx.done = true
+	for _, effect := range tx.effects {
+		effect()
+	}
+}
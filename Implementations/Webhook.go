@@ -0,0 +1,236 @@
+package Implementations
+
+import (
+	"ParkingLot_go/Exceptions"
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	WebhookSignatureHeader = "X-Parking-Signature"
+	WebhookTimestampHeader = "X-Parking-Timestamp"
+	WebhookDeliveryHeader  = "X-Parking-Delivery"
+)
+
+type DeliveryStatus string
+
+const (
+	Delivered      DeliveryStatus = "DELIVERED"
+	DeliveryFailed DeliveryStatus = "FAILED"
+)
+
+type WebhookDelivery struct {
+	DeliveryId    string
+	URL           string
+	EventType     EventType
+	Status        DeliveryStatus
+	Attempts      int
+	StatusCode    int
+	Err           error
+	LastAttemptAt time.Time
+}
+
+type webhookPayload struct {
+	DeliveryId string `json:"delivery_id"`
+	Message    string `json:"message"`
+	Event      Event  `json:"event"`
+}
+
+// Webhook POSTs each event as JSON, with a message rendered for Recipient,
+// to every configured URL. Requests are signed with HMAC-SHA256 over
+// "<timestamp>.<body>" so receivers can check they came from us and are
+// recent.
+//
+// A webhook is an EventHandler, not a Notifiable: subscribe it to an EventBus,
+// which delivers off the parking path and retries failed deliveries with the
+// subscriber's backoff.
+type Webhook struct {
+	URLs       []string
+	Secret     string
+	Recipient  Recipient
+	Catalog    *MessageCatalog
+	Client     *http.Client
+	Clock      Clock
+	mutex      sync.Mutex
+	deliveries []WebhookDelivery
+	retrying   map[string]map[string]int
+}
+
+func WebhookConstruct(secret string, urls ...string) *Webhook {
+	return &Webhook{
+		URLs:   append([]string{}, urls...),
+		Secret: secret,
+		Client: &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+func SignWebhook(secret string, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp + "."))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+func VerifyWebhook(secret string, timestamp string, body []byte, signature string) bool {
+	return hmac.Equal([]byte(SignWebhook(secret, timestamp, body)), []byte(signature))
+}
+
+// Handle makes one attempt to deliver the event to every URL and reports the
+// ones that failed. When the bus retries the event, URLs that already took it
+// are skipped and the others are posted again under the same delivery ID.
+// Client errors other than 429 are not posted again; when they are all that
+// failed the error is Permanent, so the bus does not retry.
+func (webhook *Webhook) Handle(event Event) error {
+	key, err := webhookKey(event)
+	if err != nil {
+		return Permanent(Exceptions.ErrWebhookDeliveryFailed.Wrap(err))
+	}
+	indexes := webhook.deliveriesFor(key, event)
+	var failures []error
+	retryable := false
+	for _, url := range webhook.URLs {
+		webhook.mutex.Lock()
+		delivery := webhook.deliveries[indexes[url]]
+		webhook.mutex.Unlock()
+		if delivery.Status != Delivered && !isPermanent(delivery) {
+			delivery = webhook.attempt(delivery, event)
+			webhook.mutex.Lock()
+			webhook.deliveries[indexes[url]] = delivery
+			webhook.mutex.Unlock()
+		}
+		if delivery.Status == DeliveryFailed {
+			failures = append(failures, Exceptions.ErrWebhookDeliveryFailed.Wrap(fmt.Errorf("%s: %w", url, delivery.Err)))
+			retryable = retryable || !isPermanent(delivery)
+		}
+	}
+	if !retryable {
+		webhook.forget(key)
+	}
+	if len(failures) > 0 && !retryable {
+		return Permanent(errors.Join(failures...))
+	}
+	return errors.Join(failures...)
+}
+
+// Abandon drops what the webhook kept for retrying the event once the bus
+// has given up on it.
+func (webhook *Webhook) Abandon(event Event) {
+	if key, err := webhookKey(event); err == nil {
+		webhook.forget(key)
+	}
+}
+
+func webhookKey(event Event) (string, error) {
+	key, err := json.Marshal(event)
+	return string(key), err
+}
+
+func (webhook *Webhook) forget(key string) {
+	webhook.mutex.Lock()
+	defer webhook.mutex.Unlock()
+	delete(webhook.retrying, key)
+}
+
+// deliveriesFor returns where the event's delivery to each URL is recorded,
+// adding records for URLs that have none yet, as on a first attempt or for a
+// URL added since the last one.
+func (webhook *Webhook) deliveriesFor(key string, event Event) map[string]int {
+	webhook.mutex.Lock()
+	defer webhook.mutex.Unlock()
+	if webhook.retrying == nil {
+		webhook.retrying = map[string]map[string]int{}
+	}
+	indexes, ok := webhook.retrying[key]
+	if !ok {
+		indexes = map[string]int{}
+		webhook.retrying[key] = indexes
+	}
+	for _, url := range webhook.URLs {
+		if _, ok := indexes[url]; ok {
+			continue
+		}
+		indexes[url] = len(webhook.deliveries)
+		webhook.deliveries = append(webhook.deliveries, WebhookDelivery{
+			DeliveryId: uuid.NewString(),
+			URL:        url,
+			EventType:  event.Type,
+			Status:     DeliveryFailed,
+		})
+	}
+	return maps.Clone(indexes)
+}
+
+func isPermanent(delivery WebhookDelivery) bool {
+	return delivery.StatusCode >= 400 && delivery.StatusCode < 500 && delivery.StatusCode != http.StatusTooManyRequests
+}
+
+func (webhook *Webhook) Deliveries() []WebhookDelivery {
+	webhook.mutex.Lock()
+	defer webhook.mutex.Unlock()
+	return append([]WebhookDelivery{}, webhook.deliveries...)
+}
+
+func (webhook *Webhook) now() time.Time {
+	if webhook.Clock == nil {
+		return time.Now()
+	}
+	return webhook.Clock.Now()
+}
+
+// attempt posts the event once. The delivery ID stays the same across
+// attempts so receivers can drop duplicates.
+func (webhook *Webhook) attempt(delivery WebhookDelivery, event Event) WebhookDelivery {
+	body, err := json.Marshal(webhookPayload{
+		DeliveryId: delivery.DeliveryId,
+		Message:    renderMessage(webhook.Catalog, event, webhook.Recipient),
+		Event:      event,
+	})
+	if err != nil {
+		delivery.Err = err
+		return delivery
+	}
+	delivery.Attempts++
+	delivery.LastAttemptAt = webhook.now()
+	delivery.StatusCode, delivery.Err = webhook.post(delivery.URL, body, delivery.DeliveryId, delivery.LastAttemptAt)
+	if delivery.Err == nil {
+		delivery.Status = Delivered
+	}
+	return delivery
+}
+
+func (webhook *Webhook) post(url string, body []byte, deliveryId string, at time.Time) (int, error) {
+	request, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return 0, err
+	}
+	timestamp := strconv.FormatInt(at.Unix(), 10)
+	request.Header.Set("Content-Type", "application/json")
+	request.Header.Set(WebhookTimestampHeader, timestamp)
+	request.Header.Set(WebhookDeliveryHeader, deliveryId)
+	request.Header.Set(WebhookSignatureHeader, SignWebhook(webhook.Secret, timestamp, body))
+	client := webhook.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+	response, err := client.Do(request)
+	if err != nil {
+		return 0, err
+	}
+	defer response.Body.Close()
+	if response.StatusCode < 200 || response.StatusCode >= 300 {
+		return response.StatusCode, fmt.Errorf("unexpected status %s", response.Status)
+	}
+	return response.StatusCode, nil
+}
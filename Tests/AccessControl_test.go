@@ -0,0 +1,208 @@
+package Tests
+
+import (
+	"ParkingLot_go/Enums"
+	"ParkingLot_go/Exceptions"
+	"ParkingLot_go/Implementations"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDirectLotParkingIsDeniedUnderAccessControl(t *testing.T) {
+	owner := Implementations.OwnerConstruct()
+	owner.EnableAccessControl(Implementations.AccessControlConstruct(nil))
+	parkingLot := owner.CreateParkingLot(2)
+
+	_, err := parkingLot.Park(&Implementations.Car{RegistrationNumber: "AP-1234", Color: Enums.RED})
+
+	assert.ErrorIs(t, err, Exceptions.ErrPermissionDenied)
+	count, err := parkingLot.CountParkedCarsAs(owner.Principal)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, count)
+}
+
+func TestAttendentWithRoleCanParkAndUnpark(t *testing.T) {
+	owner := Implementations.OwnerConstruct()
+	owner.EnableAccessControl(Implementations.AccessControlConstruct(nil))
+	parkingLot := owner.CreateParkingLot(2)
+	attendent := Implementations.AttendentConstructDefault()
+	attendent.Principal = Implementations.PrincipalConstruct("ravi", Implementations.RoleAttendent)
+	assert.NoError(t, owner.AssignParkingLotToAttendent(attendent, parkingLot))
+
+	ticket, err := attendent.Park(&Implementations.Car{RegistrationNumber: "AP-1234", Color: Enums.RED})
+	assert.NoError(t, err)
+	_, err = attendent.Unpark(ticket)
+	assert.NoError(t, err)
+}
+
+func TestAttendentWithoutPrincipalIsDenied(t *testing.T) {
+	owner := Implementations.OwnerConstruct()
+	owner.EnableAccessControl(Implementations.AccessControlConstruct(nil))
+	parkingLot := owner.CreateParkingLot(2)
+	attendent := Implementations.AttendentConstructDefault()
+	owner.AssignParkingLotToAttendent(attendent, parkingLot)
+
+	_, err := attendent.Park(&Implementations.Car{RegistrationNumber: "AP-1234", Color: Enums.RED})
+
+	assert.ErrorIs(t, err, Exceptions.ErrPermissionDenied)
+	assert.Empty(t, attendent.ParkedCars)
+}
+
+func TestPoliceCanQueryButNotPark(t *testing.T) {
+	owner := Implementations.OwnerConstruct()
+	owner.EnableAccessControl(Implementations.AccessControlConstruct(nil))
+	parkingLot := owner.CreateParkingLot(2)
+	officer := Implementations.PrincipalConstruct("officer", Implementations.RolePolice)
+	parkingLot.ParkAs(owner.Principal, &Implementations.Car{RegistrationNumber: "AP-1234", Color: Enums.RED})
+
+	found, err := parkingLot.IsCarWithRegistrationNumberParkedAs(officer, "AP-1234")
+	assert.NoError(t, err)
+	assert.True(t, found)
+
+	_, err = parkingLot.ParkAs(officer, &Implementations.Car{RegistrationNumber: "AP-5678", Color: Enums.BLUE})
+	assert.ErrorIs(t, err, Exceptions.ErrPermissionDenied)
+	_, err = parkingLot.IsCarWithRegistrationNumberParked("AP-1234")
+	assert.ErrorIs(t, err, Exceptions.ErrPermissionDenied)
+}
+
+func TestOwnerWithoutRoleCannotCreateLotsOrAssign(t *testing.T) {
+	accessControl := Implementations.AccessControlConstruct(nil)
+	owner := Implementations.OwnerConstruct()
+	parkingLot := owner.CreateParkingLot(1)
+	owner.Principal = Implementations.PrincipalConstruct("intern", Implementations.RoleAttendent)
+	owner.EnableAccessControl(accessControl)
+
+	_, err := owner.CreateParkingLotAs(owner.Principal, 2)
+	assert.ErrorIs(t, err, Exceptions.ErrPermissionDenied)
+	err = owner.AssignParkingLotToAttendent(Implementations.AttendentConstructDefault(), parkingLot)
+	assert.ErrorIs(t, err, Exceptions.ErrPermissionDenied)
+}
+
+func TestGrantAndRevokePermissions(t *testing.T) {
+	accessControl := Implementations.AccessControlConstruct(nil)
+	officer := Implementations.PrincipalConstruct("officer", Implementations.RolePolice)
+
+	assert.ErrorIs(t, accessControl.Check(officer, Implementations.PermissionPark, 1), Exceptions.ErrPermissionDenied)
+	accessControl.Grant(Implementations.RolePolice, Implementations.PermissionPark)
+	assert.NoError(t, accessControl.Check(officer, Implementations.PermissionPark, 1))
+	accessControl.Revoke(Implementations.RolePolice, Implementations.PermissionPark)
+	assert.ErrorIs(t, accessControl.Check(officer, Implementations.PermissionPark, 1), Exceptions.ErrPermissionDenied)
+}
+
+func TestEveryCheckIsAuditLogged(t *testing.T) {
+	clock := newManualClock()
+	accessControl := Implementations.AccessControlConstruct(clock)
+	owner := Implementations.OwnerConstruct()
+	owner.EnableAccessControl(accessControl)
+	parkingLot, err := owner.CreateParkingLotAs(owner.Principal, 2)
+	assert.NoError(t, err)
+	parkingLot.Park(&Implementations.Car{RegistrationNumber: "AP-1234", Color: Enums.RED})
+
+	auditLog := accessControl.AuditLog()
+
+	assert.Len(t, auditLog, 2)
+	assert.Equal(t, Implementations.AccessDecision{Principal: owner.Principal.Name, Permission: Implementations.PermissionCreateLot, Allowed: true, At: clock.Now()}, auditLog[0])
+	assert.Equal(t, Implementations.AccessDecision{Principal: "anonymous", Permission: Implementations.PermissionPark, ParkingLotId: parkingLot.GetParkingLotId(), Allowed: false, At: clock.Now()}, auditLog[1])
+}
+
+func TestShiftReportsRequireViewPermission(t *testing.T) {
+	roster := Implementations.RosterConstruct(newManualClock())
+	roster.AccessControl = Implementations.AccessControlConstruct(nil)
+	attendent := Implementations.AttendentConstructDefault()
+
+	_, err := roster.ShiftReportsAs(Implementations.PrincipalConstruct("ravi", Implementations.RoleAttendent), attendent)
+	assert.ErrorIs(t, err, Exceptions.ErrPermissionDenied)
+	_, err = roster.ShiftReportsAs(Implementations.PrincipalConstruct("asha", Implementations.RoleSupervisor), attendent)
+	assert.NoError(t, err)
+}
+
+func TestAttendentCanOnlyParkAtAssignedLots(t *testing.T) {
+	owner := Implementations.OwnerConstruct()
+	owner.EnableAccessControl(Implementations.AccessControlConstruct(nil))
+	assignedLot := owner.CreateParkingLot(2)
+	otherLot := owner.CreateParkingLot(2)
+	attendent := Implementations.AttendentConstructDefault()
+	attendent.Principal = Implementations.PrincipalConstruct("ravi", Implementations.RoleAttendent)
+	assert.NoError(t, owner.AssignParkingLotToAttendent(attendent, assignedLot))
+
+	_, err := assignedLot.ParkAs(attendent.Principal, &Implementations.Car{RegistrationNumber: "AP-1234", Color: Enums.RED})
+	assert.NoError(t, err)
+	_, err = otherLot.ParkAs(attendent.Principal, &Implementations.Car{RegistrationNumber: "AP-5678", Color: Enums.RED})
+	assert.ErrorIs(t, err, Exceptions.ErrPermissionDenied)
+}
+
+func TestOwnersCannotActAtEachOthersLots(t *testing.T) {
+	accessControl := Implementations.AccessControlConstruct(nil)
+	owner := Implementations.OwnerConstruct()
+	owner.EnableAccessControl(accessControl)
+	otherOwner := Implementations.OwnerConstruct()
+	otherOwner.EnableAccessControl(accessControl)
+	otherLot := otherOwner.CreateParkingLot(2)
+
+	assert.NotEqual(t, owner.Principal.Name, otherOwner.Principal.Name)
+	_, err := otherLot.ParkAs(owner.Principal, &Implementations.Car{RegistrationNumber: "AP-1234", Color: Enums.RED})
+	assert.ErrorIs(t, err, Exceptions.ErrPermissionDenied)
+	_, err = otherLot.ParkAs(otherOwner.Principal, &Implementations.Car{RegistrationNumber: "AP-1234", Color: Enums.RED})
+	assert.NoError(t, err)
+}
+
+func TestReportsRequireViewPermission(t *testing.T) {
+	owner := Implementations.OwnerConstruct()
+	owner.EnableAccessControl(Implementations.AccessControlConstruct(nil))
+	parkingLot := owner.CreateParkingLot(2)
+	attendent := Implementations.AttendentConstructDefault()
+	attendent.Principal = Implementations.PrincipalConstruct("ravi", Implementations.RoleAttendent)
+	assert.NoError(t, owner.AssignParkingLotToAttendent(attendent, parkingLot))
+	ticket, err := attendent.Park(&Implementations.Car{RegistrationNumber: "AP-1234", Color: Enums.RED})
+	assert.NoError(t, err)
+
+	_, err = parkingLot.IsFullAs(attendent.Principal)
+	assert.ErrorIs(t, err, Exceptions.ErrPermissionDenied)
+	_, err = parkingLot.CountCarsByColorAs(attendent.Principal, Enums.RED)
+	assert.ErrorIs(t, err, Exceptions.ErrPermissionDenied)
+	_, err = attendent.ExplainAs(attendent.Principal, ticket.GetTicketId())
+	assert.ErrorIs(t, err, Exceptions.ErrPermissionDenied)
+	_, err = attendent.MetricsAs(attendent.Principal)
+	assert.ErrorIs(t, err, Exceptions.ErrPermissionDenied)
+	_, err = attendent.ActivityForAs(attendent.Principal, owner)
+	assert.ErrorIs(t, err, Exceptions.ErrPermissionDenied)
+	assert.ErrorIs(t, attendent.ValidateAs(attendent.Principal), Exceptions.ErrPermissionDenied)
+
+	count, err := parkingLot.CountCarsByColorAs(owner.Principal, Enums.RED)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, count)
+	decision, err := attendent.ExplainAs(owner.Principal, ticket.GetTicketId())
+	assert.NoError(t, err)
+	assert.Equal(t, parkingLot.GetParkingLotId(), decision.SelectedParkingLotId)
+	_, err = attendent.MetricsAs(owner.Principal)
+	assert.NoError(t, err)
+	_, err = attendent.ActivityForAs(owner.Principal, owner)
+	assert.NoError(t, err)
+	assert.NoError(t, attendent.ValidateAs(owner.Principal))
+}
+
+func TestSupervisorCanOnlyActAtItsTeamsLots(t *testing.T) {
+	owner := Implementations.OwnerConstruct()
+	owner.EnableAccessControl(Implementations.AccessControlConstruct(nil))
+	teamLot := owner.CreateParkingLot(2)
+	otherLot := owner.CreateParkingLot(2)
+	attendent := Implementations.AttendentConstructDefault()
+	attendent.Principal = Implementations.PrincipalConstruct("ravi", Implementations.RoleAttendent)
+	supervisor := Implementations.SupervisorConstructAs(Implementations.PrincipalConstruct("asha", Implementations.RoleSupervisor), attendent)
+	assert.NoError(t, owner.AssignParkingLotToAttendent(attendent, teamLot))
+
+	_, err := teamLot.ParkAs(supervisor.Principal, &Implementations.Car{RegistrationNumber: "AP-1234", Color: Enums.RED})
+	assert.NoError(t, err)
+	_, err = otherLot.ParkAs(supervisor.Principal, &Implementations.Car{RegistrationNumber: "AP-5678", Color: Enums.RED})
+	assert.ErrorIs(t, err, Exceptions.ErrPermissionDenied)
+}
+
+func TestLotScopedRolesGetNothingWithoutALot(t *testing.T) {
+	accessControl := Implementations.AccessControlConstruct(nil)
+	owner := Implementations.PrincipalConstruct("owner", Implementations.RoleOwner)
+
+	assert.ErrorIs(t, accessControl.Check(owner, Implementations.PermissionPark, 0), Exceptions.ErrPermissionDenied)
+	assert.NoError(t, accessControl.CheckGlobal(owner, Implementations.PermissionCreateLot))
+	assert.ErrorIs(t, accessControl.CheckGlobal(nil, Implementations.PermissionCreateLot), Exceptions.ErrPermissionDenied)
+}
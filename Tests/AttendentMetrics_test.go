@@ -0,0 +1,170 @@
+package Tests
+
+import (
+	"ParkingLot_go/Enums"
+	"ParkingLot_go/Exceptions"
+	"ParkingLot_go/Implementations"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMetricsCountParksAndRetrievalTimes(t *testing.T) {
+	clock := newManualClock()
+	start := clock.Now()
+	owner := Implementations.OwnerConstruct()
+	parkingLot := owner.CreateParkingLot(3)
+	attendent := Implementations.AttendentConstructDefault()
+	attendent.Clock = clock
+	owner.AssignParkingLotToAttendent(attendent, parkingLot)
+
+	ticket, err := attendent.Park(&Implementations.Car{RegistrationNumber: "AP-1", Color: Enums.RED})
+	assert.NoError(t, err)
+	attendent.Park(&Implementations.Car{RegistrationNumber: "AP-2", Color: Enums.BLUE})
+	clock.Advance(30 * time.Minute)
+	assert.NoError(t, attendent.RequestRetrieval(ticket))
+	clock.Advance(3 * time.Minute)
+	_, err = attendent.Unpark(ticket)
+	assert.NoError(t, err)
+
+	summary := attendent.Metrics().Summary(start, start.Add(time.Hour))
+	assert.Equal(t, 2, summary.CarsParked)
+	assert.Equal(t, 1, summary.CarsRetrieved)
+	assert.Equal(t, 2.0, summary.ParkedPerHour)
+	assert.Equal(t, 3*time.Minute, summary.AverageRetrievalTime)
+	assert.Equal(t, []int{0, 0, 1, 0, 0, 0, 0}, summary.RetrievalTimes.Counts)
+	assert.Equal(t, []int{parkingLot.GetParkingLotId()}, summary.LotsServed)
+}
+
+func TestUnparkWithoutRetrievalRequestCountsButIsNotTimed(t *testing.T) {
+	clock := newManualClock()
+	owner := Implementations.OwnerConstruct()
+	attendent := Implementations.AttendentConstructDefault()
+	attendent.Clock = clock
+	owner.AssignParkingLotToAttendent(attendent, owner.CreateParkingLot(1))
+
+	ticket, err := attendent.Park(&Implementations.Car{RegistrationNumber: "AP-1", Color: Enums.RED})
+	assert.NoError(t, err)
+	clock.Advance(10 * time.Minute)
+	_, err = attendent.Unpark(ticket)
+	assert.NoError(t, err)
+
+	summary := attendent.Metrics().Summary(clock.Now().Add(-time.Hour), clock.Now().Add(time.Hour))
+	assert.Equal(t, 1, summary.CarsRetrieved)
+	assert.Equal(t, 0.5, summary.RetrievedPerHour)
+	assert.Equal(t, time.Duration(0), summary.AverageRetrievalTime)
+}
+
+func TestPendingRetrievalRequestFollowsAHandover(t *testing.T) {
+	clock := newManualClock()
+	roster := Implementations.RosterConstruct(clock)
+	owner := Implementations.OwnerConstruct()
+	outgoing := Implementations.AttendentConstructDefault()
+	incoming := Implementations.AttendentConstructDefault()
+	outgoing.Clock = clock
+	incoming.Clock = clock
+	owner.AssignParkingLotToAttendent(outgoing, owner.CreateParkingLot(1))
+	roster.Schedule(outgoing, clock.Now(), clock.Now().Add(8*time.Hour))
+	roster.Schedule(incoming, clock.Now(), clock.Now().Add(8*time.Hour))
+	roster.ClockIn(outgoing)
+	roster.ClockIn(incoming)
+	ticket, _ := outgoing.Park(&Implementations.Car{RegistrationNumber: "AP-1", Color: Enums.RED})
+	outgoing.RequestRetrieval(ticket)
+	clock.Advance(time.Minute)
+
+	_, err := roster.Handover(outgoing, incoming)
+	assert.NoError(t, err)
+	clock.Advance(3 * time.Minute)
+	_, err = incoming.Unpark(ticket)
+	assert.NoError(t, err)
+
+	summary := incoming.Metrics().Summary(clock.Now().Add(-time.Hour), clock.Now().Add(time.Hour))
+	assert.Equal(t, 1, summary.CarsRetrieved)
+	assert.Equal(t, 4*time.Minute, summary.AverageRetrievalTime)
+}
+
+func TestPendingRetrievalRequestFollowsARevocation(t *testing.T) {
+	clock := newManualClock()
+	owner := Implementations.OwnerConstruct()
+	owner.Clock = clock
+	parkingLot := owner.CreateParkingLot(1)
+	attendent := Implementations.AttendentConstructDefault()
+	attendent.Clock = clock
+	owner.AssignParkingLotToAttendent(attendent, parkingLot)
+	ticket, _ := attendent.Park(&Implementations.Car{RegistrationNumber: "AP-1", Color: Enums.RED})
+	attendent.RequestRetrieval(ticket)
+
+	_, err := owner.RevokeParkingLotFromAttendent(attendent, parkingLot)
+	assert.NoError(t, err)
+	clock.Advance(5 * time.Minute)
+	_, err = owner.Unpark(ticket)
+	assert.NoError(t, err)
+
+	summary := owner.Metrics().Summary(clock.Now().Add(-time.Hour), clock.Now().Add(time.Hour))
+	assert.Equal(t, 1, summary.CarsRetrieved)
+	assert.Equal(t, 5*time.Minute, summary.AverageRetrievalTime)
+}
+
+func TestMetricsRecordFailedParksByReason(t *testing.T) {
+	clock := newManualClock()
+	owner := Implementations.OwnerConstruct()
+	parkingLot := owner.CreateParkingLot(1)
+	attendent := Implementations.AttendentConstructDefault()
+	attendent.Clock = clock
+	owner.AssignParkingLotToAttendent(attendent, parkingLot)
+	car := &Implementations.Car{RegistrationNumber: "AP-1", Color: Enums.RED}
+
+	attendent.Park(car)
+	attendent.Park(car)
+	attendent.Park(&Implementations.Car{RegistrationNumber: "AP-2", Color: Enums.RED})
+
+	summary := attendent.Metrics().Summary(clock.Now(), clock.Now().Add(time.Hour))
+	assert.Equal(t, 2, summary.FailedParks)
+	assert.Equal(t, 1, summary.FailedParksByReason[Exceptions.CodeCarAlreadyAssigned])
+	assert.Equal(t, 1, summary.FailedParksByReason[Exceptions.CodeAllParkingLotsFull])
+}
+
+func TestMetricsSummaryOnlyCoversTheWindow(t *testing.T) {
+	clock := newManualClock()
+	owner := Implementations.OwnerConstruct()
+	attendent := Implementations.AttendentConstructDefault()
+	attendent.Clock = clock
+	owner.AssignParkingLotToAttendent(attendent, owner.CreateParkingLot(3))
+
+	attendent.Park(&Implementations.Car{RegistrationNumber: "AP-1", Color: Enums.RED})
+	clock.Advance(2 * time.Hour)
+	windowStart := clock.Now()
+	attendent.Park(&Implementations.Car{RegistrationNumber: "AP-2", Color: Enums.RED})
+
+	summary := attendent.Metrics().Summary(windowStart, windowStart.Add(time.Hour))
+	assert.Equal(t, 1, summary.CarsParked)
+}
+
+func TestOwnerReportComparesAttendents(t *testing.T) {
+	clock := newManualClock()
+	owner := Implementations.OwnerConstruct()
+	busy := Implementations.AttendentConstructDefault()
+	idle := Implementations.AttendentConstructDefault()
+	busy.Clock = clock
+	idle.Clock = clock
+	owner.AssignParkingLotToAttendent(busy, owner.CreateParkingLot(2))
+	owner.AssignParkingLotToAttendent(idle, owner.CreateParkingLot(2))
+	busy.Park(&Implementations.Car{RegistrationNumber: "AP-1", Color: Enums.RED})
+
+	report, err := owner.AttendentPerformanceReport(clock.Now(), clock.Now().Add(time.Hour))
+	assert.NoError(t, err)
+	assert.Len(t, report, 2)
+	assert.Equal(t, 1, report[0].Summary.CarsParked)
+	assert.Equal(t, 0, report[1].Summary.CarsParked)
+}
+
+func TestOwnerReportRequiresViewReportsPermission(t *testing.T) {
+	owner := Implementations.OwnerConstruct()
+	accessControl := Implementations.AccessControlConstruct(nil)
+	owner.EnableAccessControl(accessControl)
+	owner.Principal = Implementations.PrincipalConstruct("clerk", Implementations.RoleAttendent)
+
+	_, err := owner.AttendentPerformanceReport(time.Now(), time.Now().Add(time.Hour))
+	assert.ErrorIs(t, err, Exceptions.ErrPermissionDenied)
+}
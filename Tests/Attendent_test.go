@@ -260,7 +260,7 @@ func TestCannotUnparkSameCarTwice(t *testing.T) {
 
 	_, err := attendent.Unpark(ticket)
 	assert.Error(t, err)
-	assert.Equal(t, "car not found", err.Error())
+	assert.Equal(t, Exceptions.ErrCarNotFound.WithTicket(ticket.GetTicketId()).Error(), err.Error())
 }
 
 func TestSmartAttendentThrowsWhenNoParkingLotAssigned(t *testing.T) {
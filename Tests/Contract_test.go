@@ -0,0 +1,174 @@
+package Tests
+
+import (
+	"ParkingLot_go/Enums"
+	"ParkingLot_go/Exceptions"
+	"ParkingLot_go/Implementations"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestContractedAttendentServesSecondOwner(t *testing.T) {
+	clock := newManualClock()
+	employer := Implementations.OwnerConstruct()
+	client := Implementations.OwnerConstruct()
+	employerLot := employer.CreateParkingLot(2)
+	clientLot := client.CreateParkingLot(2)
+	attendent := Implementations.AttendentConstructDefault()
+	attendent.Clock = clock
+	employer.AssignParkingLotToAttendent(attendent, employerLot)
+
+	_, err := client.ContractAttendent(attendent, []*Implementations.ParkingLot{clientLot}, clock.Now(), clock.Now().Add(24*time.Hour), 250)
+	assert.NoError(t, err)
+	assert.Contains(t, client.Attendents, attendent)
+
+	_, err = attendent.ParkFor(client, &Implementations.Car{RegistrationNumber: "AP-1234", Color: Enums.RED})
+	assert.NoError(t, err)
+	assert.Equal(t, 1, clientLot.CountParkedCars())
+	assert.Equal(t, 0, employerLot.CountParkedCars())
+}
+
+func TestAssignWithoutContractStillRejectedForOtherOwners(t *testing.T) {
+	employer := Implementations.OwnerConstruct()
+	client := Implementations.OwnerConstruct()
+	attendent := Implementations.AttendentConstructDefault()
+	employer.AssignParkingLotToAttendent(attendent, employer.CreateParkingLot(2))
+
+	err := client.AssignParkingLotToAttendent(attendent, client.CreateParkingLot(2))
+	assert.ErrorIs(t, err, Exceptions.ErrParkingLotNotOwned)
+}
+
+func TestAssignUnderContractExtendsTheContract(t *testing.T) {
+	clock := newManualClock()
+	employer := Implementations.OwnerConstruct()
+	client := Implementations.OwnerConstruct()
+	attendent := Implementations.AttendentConstructDefault()
+	employer.AssignParkingLotToAttendent(attendent, employer.CreateParkingLot(2))
+	contract, _ := client.ContractAttendent(attendent, []*Implementations.ParkingLot{client.CreateParkingLot(1)}, clock.Now(), clock.Now().Add(time.Hour), 0)
+	extraLot := client.CreateParkingLot(1)
+
+	assert.NoError(t, client.AssignParkingLotToAttendent(attendent, extraLot))
+	assert.Contains(t, contract.ParkingLots, extraLot)
+}
+
+func TestExpiredContractTakesLotsOutOfScope(t *testing.T) {
+	clock := newManualClock()
+	employer := Implementations.OwnerConstruct()
+	client := Implementations.OwnerConstruct()
+	clientLot := client.CreateParkingLot(2)
+	attendent := Implementations.AttendentConstructDefault()
+	attendent.Clock = clock
+	employer.AssignParkingLotToAttendent(attendent, employer.CreateParkingLot(2))
+	client.ContractAttendent(attendent, []*Implementations.ParkingLot{clientLot}, clock.Now(), clock.Now().Add(8*time.Hour), 0)
+	ticket, _ := attendent.ParkFor(client, &Implementations.Car{RegistrationNumber: "AP-1234", Color: Enums.RED})
+
+	clock.Advance(9 * time.Hour)
+
+	_, err := attendent.ParkFor(client, &Implementations.Car{RegistrationNumber: "AP-5678", Color: Enums.BLUE})
+	assert.ErrorIs(t, err, Exceptions.ErrContractNotActive)
+	_, err = attendent.Unpark(ticket)
+	assert.ErrorIs(t, err, Exceptions.ErrContractNotActive)
+	_, err = attendent.Park(&Implementations.Car{RegistrationNumber: "AP-5678", Color: Enums.BLUE})
+	assert.NoError(t, err)
+}
+
+func TestContractRejectsLotsNotOwnedAndInvalidPeriods(t *testing.T) {
+	clock := newManualClock()
+	client := Implementations.OwnerConstruct()
+	stranger := Implementations.OwnerConstruct()
+	attendent := Implementations.AttendentConstructDefault()
+
+	_, err := client.ContractAttendent(attendent, []*Implementations.ParkingLot{stranger.CreateParkingLot(1)}, clock.Now(), clock.Now().Add(time.Hour), 0)
+	assert.ErrorIs(t, err, Exceptions.ErrParkingLotNotOwned)
+	_, err = client.ContractAttendent(attendent, []*Implementations.ParkingLot{client.CreateParkingLot(1)}, clock.Now(), clock.Now(), 0)
+	assert.ErrorIs(t, err, Exceptions.ErrInvalidContract)
+}
+
+func TestContractRejectsPeriodsOverlappingAnEarlierContract(t *testing.T) {
+	clock := newManualClock()
+	client := Implementations.OwnerConstruct()
+	clientLot := client.CreateParkingLot(1)
+	attendent := Implementations.AttendentConstructDefault()
+	_, err := client.ContractAttendent(attendent, []*Implementations.ParkingLot{clientLot}, clock.Now(), clock.Now().Add(8*time.Hour), 0)
+	assert.NoError(t, err)
+
+	_, err = client.ContractAttendent(attendent, []*Implementations.ParkingLot{clientLot}, clock.Now().Add(4*time.Hour), clock.Now().Add(12*time.Hour), 0)
+	assert.ErrorIs(t, err, Exceptions.ErrOverlappingContract)
+	assert.Len(t, attendent.Contracts, 1)
+}
+
+func TestLotCanBeContractedAgainOnceTheEarlierContractEnds(t *testing.T) {
+	clock := newManualClock()
+	client := Implementations.OwnerConstruct()
+	clientLot := client.CreateParkingLot(3)
+	attendent := Implementations.AttendentConstructDefault()
+	attendent.Clock = clock
+	client.ContractAttendent(attendent, []*Implementations.ParkingLot{clientLot}, clock.Now(), clock.Now().Add(8*time.Hour), 10)
+
+	_, err := client.ContractAttendent(attendent, []*Implementations.ParkingLot{clientLot}, clock.Now().Add(8*time.Hour), clock.Now().Add(16*time.Hour), 20)
+	assert.NoError(t, err)
+	assert.Len(t, attendent.AssignedParkingLots, 1)
+
+	attendent.ParkFor(client, &Implementations.Car{RegistrationNumber: "AP-0001", Color: Enums.RED})
+	clock.Advance(9 * time.Hour)
+	_, err = attendent.ParkFor(client, &Implementations.Car{RegistrationNumber: "AP-0002", Color: Enums.RED})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(30), attendent.ActivityFor(client).Revenue)
+}
+
+func TestActivityAndRevenueAttributedPerOwner(t *testing.T) {
+	clock := newManualClock()
+	employer := Implementations.OwnerConstruct()
+	client := Implementations.OwnerConstruct()
+	clientLot := client.CreateParkingLot(3)
+	attendent := Implementations.AttendentConstructDefault()
+	attendent.Clock = clock
+	employer.AssignParkingLotToAttendent(attendent, employer.CreateParkingLot(3))
+	client.ContractAttendent(attendent, []*Implementations.ParkingLot{clientLot}, clock.Now(), clock.Now().Add(8*time.Hour), 400)
+
+	attendent.Park(&Implementations.Car{RegistrationNumber: "AP-0001", Color: Enums.RED})
+	ticket, _ := attendent.ParkFor(client, &Implementations.Car{RegistrationNumber: "AP-0002", Color: Enums.RED})
+	attendent.ParkFor(client, &Implementations.Car{RegistrationNumber: "AP-0003", Color: Enums.RED})
+	attendent.Unpark(ticket)
+
+	employerActivity := attendent.ActivityFor(employer)
+	assert.Equal(t, Implementations.OwnerActivity{CarsParked: 1}, employerActivity)
+	clientActivity := attendent.ActivityFor(client)
+	assert.Equal(t, Implementations.OwnerActivity{CarsParked: 2, CarsUnparked: 1, Revenue: 800}, clientActivity)
+}
+
+func TestClientDispatchOnlyUsesItsOwnLots(t *testing.T) {
+	clock := newManualClock()
+	employer := Implementations.OwnerConstruct()
+	client := Implementations.OwnerConstruct()
+	employerLot := employer.CreateParkingLot(3)
+	clientLot := client.CreateParkingLot(3)
+	attendent := Implementations.AttendentConstructDefault()
+	attendent.Clock = clock
+	employer.AssignParkingLotToAttendent(attendent, employerLot)
+	client.ContractAttendent(attendent, []*Implementations.ParkingLot{clientLot}, clock.Now(), clock.Now().Add(time.Hour), 0)
+
+	_, err := client.DispatchPark(&Implementations.Car{RegistrationNumber: "AP-1234", Color: Enums.RED})
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, clientLot.CountParkedCars())
+	assert.Equal(t, 0, employerLot.CountParkedCars())
+}
+
+func TestFiringByClientEndsContractButKeepsEmployer(t *testing.T) {
+	clock := newManualClock()
+	employer := Implementations.OwnerConstruct()
+	client := Implementations.OwnerConstruct()
+	attendent := Implementations.AttendentConstructDefault()
+	employer.AssignParkingLotToAttendent(attendent, employer.CreateParkingLot(1))
+	client.ContractAttendent(attendent, []*Implementations.ParkingLot{client.CreateParkingLot(1)}, clock.Now(), clock.Now().Add(time.Hour), 0)
+
+	_, err := client.FireAttendent(attendent, nil)
+
+	assert.NoError(t, err)
+	assert.Equal(t, employer, attendent.AssignedOwner)
+	assert.Empty(t, attendent.Contracts)
+	assert.Len(t, attendent.AssignedParkingLots, 1)
+}
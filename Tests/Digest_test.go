@@ -0,0 +1,138 @@
+package Tests
+
+import (
+	"ParkingLot_go/Enums"
+	"ParkingLot_go/Implementations"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type digestRecorder struct {
+	eventRecorder
+	digests []Implementations.Digest
+}
+
+func (recorder *digestRecorder) ReceiveDigest(digest Implementations.Digest) {
+	recorder.digests = append(recorder.digests, digest)
+}
+
+func digestLot(t *testing.T, clock *manualClock, recorder *digestRecorder, preferences Implementations.DeliveryPreferences) (*Implementations.ParkingLot, *Implementations.DigestNotifier) {
+	owner := Implementations.OwnerConstruct()
+	owner.Clock = clock
+	parkingLot := owner.CreateParkingLot(1)
+	notifier := Implementations.DigestNotifierConstruct(recorder, preferences, clock)
+	parkingLot.Subscribe(notifier)
+	return parkingLot, notifier
+}
+
+func TestHourlyDigestSummarisesFillsPeakAndTimeFull(t *testing.T) {
+	clock := newManualClock()
+	recorder := &digestRecorder{}
+	parkingLot, notifier := digestLot(t, clock, recorder, Implementations.DeliveryPreferences{Mode: Implementations.HourlyDigest})
+	parkingLot.Name = "Central Garage"
+
+	ticket, _ := parkingLot.Park(&Implementations.Car{RegistrationNumber: "AP-1", Color: Enums.RED})
+	clock.Advance(15 * time.Minute)
+	parkingLot.Unpark(ticket)
+	clock.Advance(15 * time.Minute)
+	parkingLot.Park(&Implementations.Car{RegistrationNumber: "AP-2", Color: Enums.RED})
+	clock.Advance(20 * time.Minute)
+	notifier.Tick()
+	assert.Empty(t, recorder.digests)
+
+	clock.Advance(15 * time.Minute)
+	notifier.Tick()
+
+	assert.Empty(t, recorder.events)
+	assert.Len(t, recorder.digests, 1)
+	digest := recorder.digests[0]
+	assert.Equal(t, newManualClock().Now().Add(time.Hour), digest.To)
+	assert.Equal(t, 2, digest.Lots[0].TimesFilled)
+	assert.Equal(t, 100.0, digest.Lots[0].PeakOccupancy)
+	assert.Equal(t, 45*time.Minute, digest.Lots[0].TimeFull)
+	assert.Equal(t, 2, digest.EventCounts[Implementations.LotFull])
+	assert.Equal(t, "Central Garage", digest.Lots[0].ParkingLotName)
+}
+
+func TestLotStillFullKeepsCountingIntoTheNextDigest(t *testing.T) {
+	clock := newManualClock()
+	recorder := &digestRecorder{}
+	parkingLot, notifier := digestLot(t, clock, recorder, Implementations.DeliveryPreferences{Mode: Implementations.DailyDigest})
+	parkingLot.Park(&Implementations.Car{RegistrationNumber: "AP-1", Color: Enums.RED})
+
+	clock.Advance(24 * time.Hour)
+	notifier.Tick()
+	clock.Advance(24 * time.Hour)
+	notifier.Tick()
+
+	assert.Len(t, recorder.digests, 2)
+	assert.Equal(t, 15*time.Hour, recorder.digests[0].Lots[0].TimeFull)
+	assert.Equal(t, 24*time.Hour, recorder.digests[1].Lots[0].TimeFull)
+	assert.Equal(t, 0, recorder.digests[1].Lots[0].TimesFilled)
+}
+
+func TestQuietHoursHoldEverythingButCriticalEvents(t *testing.T) {
+	clock := newManualClock()
+	recorder := &digestRecorder{}
+	parkingLot, notifier := digestLot(t, clock, recorder, Implementations.DeliveryPreferences{
+		Mode:       Implementations.Immediate,
+		QuietHours: &Implementations.QuietHours{Start: 22 * time.Hour, End: 7 * time.Hour},
+	})
+	parkingLot.SetOverstayLimits(Implementations.OverstayLimits{Default: time.Hour, TowEligibleAfter: time.Hour})
+	sweeper := Implementations.OverstaySweeperConstruct(clock, parkingLot)
+
+	clock.Advance(14 * time.Hour)
+	parkingLot.Park(&Implementations.Car{RegistrationNumber: "AP-1", Color: Enums.RED})
+	clock.Advance(3 * time.Hour)
+	sweeper.Sweep()
+	assert.Equal(t, []Implementations.EventType{Implementations.VehicleOverstayed}, recorder.types())
+	assert.Equal(t, Implementations.TowEligible, recorder.events[0].Stage)
+
+	clock.Advance(6 * time.Hour)
+	notifier.Tick()
+	assert.Len(t, recorder.digests, 1)
+	assert.Equal(t, 1, recorder.digests[0].Lots[0].TimesFilled)
+}
+
+func TestImmediateDeliveryOutsideQuietHours(t *testing.T) {
+	clock := newManualClock()
+	recorder := &digestRecorder{}
+	parkingLot, _ := digestLot(t, clock, recorder, Implementations.DeliveryPreferences{
+		QuietHours: &Implementations.QuietHours{Start: 22 * time.Hour, End: 7 * time.Hour},
+	})
+
+	parkingLot.Park(&Implementations.Car{RegistrationNumber: "AP-1", Color: Enums.RED})
+
+	assert.Equal(t, []Implementations.EventType{Implementations.CarParked, Implementations.LotFull}, recorder.types())
+	assert.Empty(t, recorder.digests)
+}
+
+func TestDigestHeldOverQuietHoursClosesAtTheCurrentHour(t *testing.T) {
+	clock := newManualClock()
+	recorder := &digestRecorder{}
+	parkingLot, notifier := digestLot(t, clock, recorder, Implementations.DeliveryPreferences{
+		Mode:       Implementations.HourlyDigest,
+		QuietHours: &Implementations.QuietHours{Start: 22 * time.Hour, End: 6 * time.Hour},
+	})
+
+	clock.Advance(12*time.Hour + 30*time.Minute)
+	notifier.Tick()
+	clock.Advance(time.Hour)
+	parkingLot.Park(&Implementations.Car{RegistrationNumber: "AP-1", Color: Enums.RED})
+	clock.Advance(8 * time.Hour)
+	notifier.Tick()
+	notifier.Tick()
+	parkingLot.Park(&Implementations.Car{RegistrationNumber: "AP-2", Color: Enums.RED})
+
+	assert.Len(t, recorder.digests, 1)
+	digest := recorder.digests[0]
+	assert.Equal(t, time.Date(2024, time.March, 5, 6, 0, 0, 0, time.UTC), digest.To)
+	assert.Equal(t, 7*time.Hour+30*time.Minute, digest.Lots[0].TimeFull)
+
+	clock.Advance(time.Hour)
+	notifier.Tick()
+	assert.Len(t, recorder.digests, 2)
+	assert.Equal(t, time.Hour, recorder.digests[1].Lots[0].TimeFull)
+}
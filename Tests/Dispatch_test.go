@@ -0,0 +1,161 @@
+package Tests
+
+import (
+	"ParkingLot_go/Enums"
+	"ParkingLot_go/Exceptions"
+	"ParkingLot_go/Implementations"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAssigningLotRegistersAttendentWithOwner(t *testing.T) {
+	owner := Implementations.OwnerConstruct()
+	parkingLot := owner.CreateParkingLot(2)
+	attendent := Implementations.AttendentConstructDefault()
+
+	owner.AssignParkingLotToAttendent(attendent, parkingLot)
+	owner.AssignParkingLotToAttendent(attendent, owner.CreateParkingLot(2))
+
+	assert.Equal(t, []*Implementations.Attendent{attendent}, owner.Attendents)
+}
+
+func TestHireAttendent(t *testing.T) {
+	owner := Implementations.OwnerConstruct()
+	otherOwner := Implementations.OwnerConstruct()
+	attendent := Implementations.AttendentConstructDefault()
+
+	assert.NoError(t, owner.HireAttendent(attendent))
+	assert.ErrorIs(t, owner.HireAttendent(attendent), Exceptions.ErrAttendentAlreadyHired)
+	assert.ErrorIs(t, otherOwner.HireAttendent(attendent), Exceptions.ErrAttendentEmployedElsewhere)
+}
+
+func TestDispatchWithoutAvailableAttendents(t *testing.T) {
+	owner := Implementations.OwnerConstruct()
+	owner.HireAttendent(Implementations.AttendentConstructDefault())
+
+	_, err := owner.DispatchPark(&Implementations.Car{RegistrationNumber: "AP-1234", Color: Enums.RED})
+	assert.ErrorIs(t, err, Exceptions.ErrNoAttendentAvailable)
+}
+
+func TestLeastBusyDispatch(t *testing.T) {
+	owner := Implementations.OwnerConstruct()
+	firstAttendent := Implementations.AttendentConstructDefault()
+	secondAttendent := Implementations.AttendentConstructDefault()
+	owner.AssignParkingLotToAttendent(firstAttendent, owner.CreateParkingLot(3))
+	owner.AssignParkingLotToAttendent(secondAttendent, owner.CreateParkingLot(3))
+
+	owner.DispatchPark(&Implementations.Car{RegistrationNumber: "AP-1234", Color: Enums.RED})
+	owner.DispatchPark(&Implementations.Car{RegistrationNumber: "AP-5678", Color: Enums.BLUE})
+
+	assert.Len(t, firstAttendent.ParkedCars, 1)
+	assert.Len(t, secondAttendent.ParkedCars, 1)
+}
+
+func TestLeastBusyDispatchSkipsAttendentsWithFullLots(t *testing.T) {
+	owner := Implementations.OwnerConstruct()
+	firstAttendent := Implementations.AttendentConstructDefault()
+	secondAttendent := Implementations.AttendentConstructDefault()
+	owner.AssignParkingLotToAttendent(firstAttendent, owner.CreateParkingLot(1))
+	owner.AssignParkingLotToAttendent(secondAttendent, owner.CreateParkingLot(5))
+	secondAttendent.Park(&Implementations.Car{RegistrationNumber: "AP-0001", Color: Enums.RED})
+	secondAttendent.Park(&Implementations.Car{RegistrationNumber: "AP-0002", Color: Enums.RED})
+
+	owner.DispatchPark(&Implementations.Car{RegistrationNumber: "AP-1234", Color: Enums.RED})
+	_, err := owner.DispatchPark(&Implementations.Car{RegistrationNumber: "AP-5678", Color: Enums.BLUE})
+
+	assert.NoError(t, err)
+	assert.Len(t, firstAttendent.ParkedCars, 1)
+	assert.Len(t, secondAttendent.ParkedCars, 3)
+}
+
+func TestRoundRobinDispatch(t *testing.T) {
+	owner := Implementations.OwnerConstruct()
+	owner.DispatchStrategy = &Implementations.RoundRobinDispatchStrategy{}
+	firstAttendent := Implementations.AttendentConstructDefault()
+	secondAttendent := Implementations.AttendentConstructDefault()
+	owner.AssignParkingLotToAttendent(firstAttendent, owner.CreateParkingLot(5))
+	owner.AssignParkingLotToAttendent(secondAttendent, owner.CreateParkingLot(5))
+
+	for _, registration := range []string{"AP-0001", "AP-0002", "AP-0003"} {
+		_, err := owner.DispatchPark(&Implementations.Car{RegistrationNumber: registration, Color: Enums.RED})
+		assert.NoError(t, err)
+	}
+
+	assert.Len(t, firstAttendent.ParkedCars, 2)
+	assert.Len(t, secondAttendent.ParkedCars, 1)
+}
+
+func TestRoundRobinKeepsItsTurnWhenAnAttendentIsUnavailable(t *testing.T) {
+	owner := Implementations.OwnerConstruct()
+	owner.DispatchStrategy = &Implementations.RoundRobinDispatchStrategy{}
+	firstAttendent := Implementations.AttendentConstructDefault()
+	secondAttendent := Implementations.AttendentConstructDefault()
+	thirdAttendent := Implementations.AttendentConstructDefault()
+	owner.AssignParkingLotToAttendent(firstAttendent, owner.CreateParkingLot(1))
+	owner.AssignParkingLotToAttendent(secondAttendent, owner.CreateParkingLot(5))
+	owner.AssignParkingLotToAttendent(thirdAttendent, owner.CreateParkingLot(5))
+
+	owner.DispatchPark(&Implementations.Car{RegistrationNumber: "AP-0001", Color: Enums.RED})
+	owner.DispatchPark(&Implementations.Car{RegistrationNumber: "AP-0002", Color: Enums.RED})
+	assert.Len(t, secondAttendent.ParkedCars, 1)
+	owner.DispatchPark(&Implementations.Car{RegistrationNumber: "AP-0003", Color: Enums.RED})
+	owner.DispatchPark(&Implementations.Car{RegistrationNumber: "AP-0004", Color: Enums.RED})
+
+	assert.Len(t, firstAttendent.ParkedCars, 1)
+	assert.Len(t, secondAttendent.ParkedCars, 2)
+	assert.Len(t, thirdAttendent.ParkedCars, 1)
+}
+
+func TestNearestEntranceDispatch(t *testing.T) {
+	owner := Implementations.OwnerConstruct()
+	owner.DispatchStrategy = &Implementations.NearestEntranceDispatchStrategy{}
+	farAttendent := Implementations.AttendentConstructDefault()
+	farAttendent.DistanceToEntrance = 40
+	nearAttendent := Implementations.AttendentConstructDefault()
+	nearAttendent.DistanceToEntrance = 5
+	owner.AssignParkingLotToAttendent(farAttendent, owner.CreateParkingLot(5))
+	owner.AssignParkingLotToAttendent(nearAttendent, owner.CreateParkingLot(5))
+
+	owner.DispatchPark(&Implementations.Car{RegistrationNumber: "AP-1234", Color: Enums.RED})
+
+	assert.Len(t, nearAttendent.ParkedCars, 1)
+	assert.Empty(t, farAttendent.ParkedCars)
+}
+
+func TestDispatchUnparkRoutesToAttendentHoldingCar(t *testing.T) {
+	owner := Implementations.OwnerConstruct()
+	owner.DispatchStrategy = &Implementations.RoundRobinDispatchStrategy{}
+	firstAttendent := Implementations.AttendentConstructDefault()
+	secondAttendent := Implementations.AttendentConstructDefault()
+	owner.AssignParkingLotToAttendent(firstAttendent, owner.CreateParkingLot(5))
+	owner.AssignParkingLotToAttendent(secondAttendent, owner.CreateParkingLot(5))
+
+	owner.DispatchPark(&Implementations.Car{RegistrationNumber: "AP-1234", Color: Enums.RED})
+	ticket, _ := owner.DispatchPark(&Implementations.Car{RegistrationNumber: "AP-5678", Color: Enums.BLUE})
+
+	car, err := owner.DispatchUnpark(ticket)
+	assert.NoError(t, err)
+	assert.Equal(t, "AP-5678", car.RegistrationNumber)
+	assert.Empty(t, secondAttendent.ParkedCars)
+
+	_, err = owner.DispatchUnpark(ticket)
+	assert.ErrorIs(t, err, Exceptions.ErrCarNotFound)
+}
+
+func TestDispatchUnparkFindsCarsParkedStraightAtTheLot(t *testing.T) {
+	owner := Implementations.OwnerConstruct()
+	attendedLot := owner.CreateParkingLot(2)
+	unattendedLot := owner.CreateParkingLot(2)
+	owner.AssignParkingLotToAttendent(Implementations.AttendentConstructDefault(), attendedLot)
+	attendedTicket, _ := attendedLot.Park(&Implementations.Car{RegistrationNumber: "AP-1234", Color: Enums.RED})
+	unattendedTicket, _ := unattendedLot.Park(&Implementations.Car{RegistrationNumber: "AP-5678", Color: Enums.BLUE})
+
+	car, err := owner.DispatchUnpark(attendedTicket)
+	assert.NoError(t, err)
+	assert.Equal(t, "AP-1234", car.RegistrationNumber)
+	car, err = owner.DispatchUnpark(unattendedTicket)
+	assert.NoError(t, err)
+	assert.Equal(t, "AP-5678", car.RegistrationNumber)
+	assert.Equal(t, 0, unattendedLot.CountParkedCars())
+}
@@ -0,0 +1,135 @@
+package Tests
+
+import (
+	"ParkingLot_go/Enums"
+	"ParkingLot_go/Exceptions"
+	"ParkingLot_go/Implementations"
+	"crypto/tls"
+	"net"
+	"testing"
+	"text/template"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEmailSentWhenLotFills(t *testing.T) {
+	server := startFakeSMTPServer(t, false, "", "")
+	notifier := Implementations.EmailNotifierConstruct(Implementations.SMTPConfig{Host: "127.0.0.1", Port: server.port()}, "lots@example.com", "owner@example.com", "police@example.com")
+	defer notifier.Close()
+	bus := Implementations.EventBusConstruct()
+	bus.Subscribe(notifier, Implementations.SubscriberOptions{Name: "email"})
+	owner := Implementations.OwnerConstruct()
+	parkingLot := owner.CreateParkingLot(1)
+	parkingLot.Subscribe(bus, Implementations.LotFull)
+
+	parkingLot.Park(&Implementations.Car{RegistrationNumber: "AP-1", Color: Enums.RED})
+	shutdown(t, bus)
+
+	mails := server.received()
+	assert.Len(t, mails, 1)
+	assert.Equal(t, "lots@example.com", mails[0].from)
+	assert.Equal(t, []string{"owner@example.com", "police@example.com"}, mails[0].to)
+	assert.Contains(t, mails[0].data, "Subject: Parking lot ")
+	assert.Contains(t, mails[0].data, "is full (100%)")
+}
+
+func TestEmailUsesCustomTemplates(t *testing.T) {
+	server := startFakeSMTPServer(t, false, "", "")
+	notifier := Implementations.EmailNotifierConstruct(Implementations.SMTPConfig{Host: "127.0.0.1", Port: server.port()}, "lots@example.com", "owner@example.com")
+	defer notifier.Close()
+	notifier.SubjectTemplate = template.Must(template.New("subject").Parse("Car {{.Vehicle.RegistrationNumber}} parked"))
+	notifier.BodyTemplate = template.Must(template.New("body").Parse("Slot {{.Slot.SlotNumber}} by {{.Actor}}"))
+
+	err := notifier.Handle(Implementations.Event{
+		Type:    Implementations.CarParked,
+		Vehicle: &Implementations.Car{RegistrationNumber: "AP-7"},
+		Slot:    Implementations.SlotAddress{ParkingLotId: 1, SlotNumber: 3},
+		Actor:   "ravi",
+	})
+
+	assert.NoError(t, err)
+	assert.Contains(t, server.received()[0].data, "Subject: Car AP-7 parked")
+	assert.Contains(t, server.received()[0].data, "Slot 3 by ravi")
+}
+
+func TestEmailUpgradesToTLSAndAuthenticates(t *testing.T) {
+	server := startFakeSMTPServer(t, true, "ops", "hunter2")
+	notifier := Implementations.EmailNotifierConstruct(Implementations.SMTPConfig{
+		Host:      "127.0.0.1",
+		Port:      server.port(),
+		Username:  "ops",
+		Password:  "hunter2",
+		StartTLS:  true,
+		TLSConfig: &tls.Config{InsecureSkipVerify: true},
+	}, "lots@example.com", "owner@example.com")
+	defer notifier.Close()
+
+	assert.NoError(t, notifier.Handle(Implementations.Event{Type: Implementations.LotFull, ParkingLotId: 1}))
+
+	mail := server.received()[0]
+	assert.True(t, mail.overTLS)
+	assert.True(t, mail.authenticated)
+}
+
+func TestEmailWithWrongPasswordFails(t *testing.T) {
+	server := startFakeSMTPServer(t, false, "ops", "hunter2")
+	notifier := Implementations.EmailNotifierConstruct(Implementations.SMTPConfig{Host: "127.0.0.1", Port: server.port(), Username: "ops", Password: "wrong"}, "lots@example.com", "owner@example.com")
+
+	err := notifier.Handle(Implementations.Event{Type: Implementations.LotFull, ParkingLotId: 1})
+
+	assert.ErrorIs(t, err, Exceptions.ErrEmailDeliveryFailed)
+	assert.Empty(t, server.received())
+}
+
+func TestEmailReusesPooledConnections(t *testing.T) {
+	server := startFakeSMTPServer(t, false, "", "")
+	notifier := Implementations.EmailNotifierConstruct(Implementations.SMTPConfig{Host: "127.0.0.1", Port: server.port(), PoolSize: 1}, "lots@example.com", "owner@example.com")
+	defer notifier.Close()
+
+	for id := 1; id <= 3; id++ {
+		assert.NoError(t, notifier.Handle(Implementations.Event{Type: Implementations.LotFull, ParkingLotId: id}))
+	}
+
+	assert.Len(t, server.received(), 3)
+	assert.Equal(t, 1, server.connectionCount())
+}
+
+func TestEmailFailuresAreRetriedByTheBus(t *testing.T) {
+	server := startFakeSMTPServer(t, false, "", "")
+	server.failNextData(2)
+	notifier := Implementations.EmailNotifierConstruct(Implementations.SMTPConfig{Host: "127.0.0.1", Port: server.port()}, "lots@example.com", "owner@example.com")
+	defer notifier.Close()
+	bus := Implementations.EventBusConstruct()
+	subscriber, _ := bus.Subscribe(notifier, Implementations.SubscriberOptions{MaxAttempts: 3, InitialBackoff: time.Millisecond})
+
+	bus.Publish(Implementations.Event{Type: Implementations.LotFull, ParkingLotId: 1})
+	shutdown(t, bus)
+
+	assert.Len(t, server.received(), 1)
+	assert.Equal(t, 2, subscriber.Stats().Retried)
+	assert.Empty(t, bus.DeadLetters())
+}
+
+func TestEmailToAServerThatStopsAnsweringTimesOut(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.NoError(t, err)
+	defer listener.Close()
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			defer conn.Close()
+		}
+	}()
+	port := listener.Addr().(*net.TCPAddr).Port
+	notifier := Implementations.EmailNotifierConstruct(Implementations.SMTPConfig{Host: "127.0.0.1", Port: port, Timeout: 50 * time.Millisecond}, "lots@example.com", "owner@example.com")
+
+	started := time.Now()
+	err = notifier.Handle(Implementations.Event{Type: Implementations.LotFull, ParkingLotId: 1})
+
+	assert.ErrorIs(t, err, Exceptions.ErrEmailDeliveryFailed)
+	assert.Less(t, time.Since(started), time.Second)
+}
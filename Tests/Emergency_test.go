@@ -0,0 +1,118 @@
+package Tests
+
+import (
+	"ParkingLot_go/Enums"
+	"ParkingLot_go/Exceptions"
+	"ParkingLot_go/Implementations"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEmergencyLotRefusesCars(t *testing.T) {
+	owner := Implementations.OwnerConstruct()
+	parkingLot := owner.CreateParkingLot(2)
+	attendent := Implementations.AttendentConstructDefault()
+	owner.AssignParkingLotToAttendent(attendent, parkingLot)
+
+	owner.DeclareEmergency(parkingLot, "fire drill")
+	_, lotErr := parkingLot.Park(&Implementations.Car{RegistrationNumber: "AP-1", Color: Enums.RED})
+	_, decision, attendentErr := attendent.ParkWithExplanation(&Implementations.Car{RegistrationNumber: "AP-2", Color: Enums.RED})
+
+	assert.True(t, parkingLot.InEmergency())
+	assert.ErrorIs(t, lotErr, Exceptions.ErrParkingLotInEmergency)
+	assert.ErrorIs(t, attendentErr, Exceptions.ErrParkingLotInEmergency)
+	candidate, _ := decision.Candidate(parkingLot.ParkingLotId)
+	assert.Equal(t, "closed for an emergency", candidate.Reason)
+	assert.Equal(t, 0, parkingLot.CountParkedCars())
+}
+
+func TestAttendentParksInAnOpenLotDuringAnEmergency(t *testing.T) {
+	owner := Implementations.OwnerConstruct()
+	closedLot := owner.CreateParkingLot(2)
+	openLot := owner.CreateParkingLot(2)
+	attendent := Implementations.AttendentConstructDefault()
+	owner.AssignParkingLotToAttendent(attendent, closedLot)
+	owner.AssignParkingLotToAttendent(attendent, openLot)
+	owner.DeclareEmergency(closedLot, "gas leak")
+
+	ticket, err := attendent.Park(&Implementations.Car{RegistrationNumber: "AP-1", Color: Enums.RED})
+
+	assert.NoError(t, err)
+	address, _ := openLot.SlotAddressOf(ticket)
+	assert.Equal(t, openLot.ParkingLotId, address.ParkingLotId)
+}
+
+func TestEmergencyIsBroadcastToEverySubscriber(t *testing.T) {
+	owner := Implementations.OwnerConstruct()
+	parkingLot := owner.CreateParkingLot(2)
+	alerts := &eventRecorder{}
+	policeman := Implementations.PolicemanConstruct()
+	policeman.Alerts = alerts
+	parkingLot.RegisterNotifiable(policeman)
+	parkedOnly := &eventRecorder{}
+	parkingLot.Subscribe(parkedOnly, Implementations.CarParked)
+
+	owner.DeclareEmergency(parkingLot, "security incident")
+
+	assert.Equal(t, []Implementations.EventType{Implementations.EmergencyDeclared}, alerts.types())
+	assert.Equal(t, []Implementations.EventType{Implementations.EmergencyDeclared, Implementations.LotEvacuated}, parkedOnly.types())
+	assert.Equal(t, "security incident", alerts.events[0].Reason)
+	assert.Equal(t, Implementations.Critical, alerts.events[0].Level())
+}
+
+func TestEmergencyTracksCarsUntilTheyLeave(t *testing.T) {
+	clock := newManualClock()
+	owner := Implementations.OwnerConstruct()
+	owner.Clock = clock
+	parkingLot := owner.CreateParkingLot(3)
+	first, _ := parkingLot.Park(&Implementations.Car{RegistrationNumber: "AP-1", Color: Enums.RED})
+	second, _ := parkingLot.Park(&Implementations.Car{RegistrationNumber: "AP-2", Color: Enums.BLUE})
+	recorder := &eventRecorder{}
+	parkingLot.Subscribe(recorder, Implementations.VehicleEvacuated, Implementations.LotEvacuated)
+
+	emergency, _ := owner.DeclareEmergency(parkingLot, "fire drill")
+	clock.Advance(10 * time.Minute)
+	parkingLot.Unpark(first)
+
+	remaining := owner.EvacuationList()
+	assert.Len(t, emergency.Vehicles(), 2)
+	assert.Len(t, remaining, 1)
+	assert.Equal(t, "AP-2", remaining[0].Vehicle.RegistrationNumber)
+	assert.Equal(t, Implementations.SlotAddress{ParkingLotId: parkingLot.ParkingLotId, SlotNumber: 2}, remaining[0].Slot)
+	assert.Equal(t, clock.Now(), emergency.Vehicles()[0].LeftAt)
+	assert.False(t, emergency.IsEvacuated())
+
+	owner.VoidTicket(parkingLot, second)
+
+	assert.True(t, emergency.IsEvacuated())
+	assert.Empty(t, owner.EvacuationList())
+	assert.Equal(t, []Implementations.EventType{
+		Implementations.EmergencyDeclared,
+		Implementations.VehicleEvacuated,
+		Implementations.VehicleEvacuated,
+		Implementations.LotEvacuated,
+	}, recorder.types())
+}
+
+func TestOwnerWideEmergencyCoversNewLotsUntilLifted(t *testing.T) {
+	owner := Implementations.OwnerConstruct()
+	parkingLot := owner.CreateParkingLot(1)
+
+	emergencies, err := owner.DeclareEmergencyAtAllLots("flood warning")
+	newLot := owner.CreateParkingLot(1)
+
+	assert.NoError(t, err)
+	assert.Len(t, emergencies, 1)
+	assert.True(t, newLot.InEmergency())
+	assert.Equal(t, "flood warning", newLot.Emergency().Reason)
+
+	owner.LiftEmergencyAtAllLots()
+
+	assert.False(t, parkingLot.InEmergency())
+	assert.False(t, emergencies[0].IsActive())
+	_, err = newLot.Park(&Implementations.Car{RegistrationNumber: "AP-1", Color: Enums.RED})
+	assert.NoError(t, err)
+	assert.ErrorIs(t, owner.LiftEmergency(parkingLot), Exceptions.ErrNoEmergency)
+}
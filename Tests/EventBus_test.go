@@ -0,0 +1,187 @@
+package Tests
+
+import (
+	"ParkingLot_go/Enums"
+	"ParkingLot_go/Exceptions"
+	"ParkingLot_go/Implementations"
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// gatedHandler holds every delivery until the gate is opened and signals
+// when the first one has arrived.
+type gatedHandler struct {
+	mutex    sync.Mutex
+	started  chan struct{}
+	gate     chan struct{}
+	once     sync.Once
+	received []int
+}
+
+func newGatedHandler() *gatedHandler {
+	return &gatedHandler{started: make(chan struct{}), gate: make(chan struct{})}
+}
+
+func (handler *gatedHandler) Handle(event Implementations.Event) error {
+	handler.once.Do(func() { close(handler.started) })
+	<-handler.gate
+	handler.mutex.Lock()
+	defer handler.mutex.Unlock()
+	handler.received = append(handler.received, event.ParkingLotId)
+	return nil
+}
+
+func lotEvent(parkingLotId int) Implementations.Event {
+	return Implementations.Event{Type: Implementations.LotFull, ParkingLotId: parkingLotId}
+}
+
+func shutdown(t *testing.T, bus *Implementations.EventBus) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	assert.NoError(t, bus.Shutdown(ctx))
+}
+
+func TestSlowSubscriberDoesNotHoldUpParking(t *testing.T) {
+	bus := Implementations.EventBusConstruct()
+	handler := newGatedHandler()
+	bus.Subscribe(handler, Implementations.SubscriberOptions{})
+	owner := Implementations.OwnerConstruct()
+	parkingLot := owner.CreateParkingLot(1)
+	parkingLot.Subscribe(bus, Implementations.LotFull)
+
+	parked := make(chan error)
+	go func() {
+		_, err := parkingLot.Park(&Implementations.Car{RegistrationNumber: "AP-1", Color: Enums.RED})
+		parked <- err
+	}()
+
+	select {
+	case err := <-parked:
+		assert.NoError(t, err)
+	case <-time.After(time.Second):
+		t.Fatal("park waited for the subscriber")
+	}
+	close(handler.gate)
+	shutdown(t, bus)
+	assert.Equal(t, []int{parkingLot.GetParkingLotId()}, handler.received)
+}
+
+func TestDropOldestKeepsTheNewestEvents(t *testing.T) {
+	bus := Implementations.EventBusConstruct()
+	handler := newGatedHandler()
+	subscriber, _ := bus.Subscribe(handler, Implementations.SubscriberOptions{BufferSize: 2, Overflow: Implementations.DropOldest})
+	bus.Publish(lotEvent(1))
+	<-handler.started
+
+	for id := 2; id <= 5; id++ {
+		bus.Publish(lotEvent(id))
+	}
+	close(handler.gate)
+	shutdown(t, bus)
+
+	assert.Equal(t, []int{1, 4, 5}, handler.received)
+	assert.Equal(t, 2, subscriber.Stats().Dropped)
+}
+
+func TestBlockPolicyMakesPublisherWait(t *testing.T) {
+	bus := Implementations.EventBusConstruct()
+	handler := newGatedHandler()
+	bus.Subscribe(handler, Implementations.SubscriberOptions{BufferSize: 1, Overflow: Implementations.Block})
+	bus.Publish(lotEvent(1))
+	<-handler.started
+	bus.Publish(lotEvent(2))
+
+	published := make(chan struct{})
+	go func() {
+		bus.Publish(lotEvent(3))
+		close(published)
+	}()
+	select {
+	case <-published:
+		t.Fatal("publish did not block on a full queue")
+	case <-time.After(50 * time.Millisecond):
+	}
+	close(handler.gate)
+	<-published
+	shutdown(t, bus)
+
+	assert.Equal(t, []int{1, 2, 3}, handler.received)
+}
+
+func TestSpillToDiskKeepsEveryEventInOrder(t *testing.T) {
+	bus := Implementations.EventBusConstruct()
+	handler := newGatedHandler()
+	subscriber, err := bus.Subscribe(handler, Implementations.SubscriberOptions{BufferSize: 1, Overflow: Implementations.SpillToDisk, SpillDirectory: t.TempDir()})
+	assert.NoError(t, err)
+	bus.Publish(lotEvent(1))
+	<-handler.started
+
+	for id := 2; id <= 5; id++ {
+		bus.Publish(lotEvent(id))
+	}
+	assert.Equal(t, 3, subscriber.Stats().Spilled)
+	close(handler.gate)
+	shutdown(t, bus)
+
+	assert.Equal(t, []int{1, 2, 3, 4, 5}, handler.received)
+}
+
+func TestFailingSubscriberIsRetriedThenDeadLettered(t *testing.T) {
+	bus := Implementations.EventBusConstruct()
+	attempts := 0
+	failure := errors.New("subscriber unavailable")
+	subscriber, _ := bus.Subscribe(Implementations.EventHandlerFunc(func(event Implementations.Event) error {
+		attempts++
+		return failure
+	}), Implementations.SubscriberOptions{Name: "ops", MaxAttempts: 3, InitialBackoff: time.Millisecond})
+
+	bus.Publish(lotEvent(7))
+	shutdown(t, bus)
+
+	assert.Equal(t, 3, attempts)
+	assert.Equal(t, 2, subscriber.Stats().Retried)
+	deadLetters := bus.DeadLetters()
+	assert.Len(t, deadLetters, 1)
+	assert.Equal(t, "ops", deadLetters[0].Subscriber)
+	assert.Equal(t, 7, deadLetters[0].Event.ParkingLotId)
+	assert.ErrorIs(t, deadLetters[0].Err, failure)
+}
+
+func TestRetrySucceedsBeforeRunningOutOfAttempts(t *testing.T) {
+	bus := Implementations.EventBusConstruct()
+	attempts := 0
+	subscriber, _ := bus.Subscribe(Implementations.EventHandlerFunc(func(event Implementations.Event) error {
+		attempts++
+		if attempts < 3 {
+			return errors.New("try again")
+		}
+		return nil
+	}), Implementations.SubscriberOptions{MaxAttempts: 5, InitialBackoff: time.Millisecond})
+
+	bus.Publish(lotEvent(1))
+	shutdown(t, bus)
+
+	assert.Equal(t, 1, subscriber.Stats().Delivered)
+	assert.Empty(t, bus.DeadLetters())
+}
+
+func TestShutdownDrainsQueuedEventsAndRefusesNewOnes(t *testing.T) {
+	bus := Implementations.EventBusConstruct()
+	recorder := &eventRecorder{}
+	bus.Subscribe(Implementations.NotifiableHandler(recorder), Implementations.SubscriberOptions{})
+	for id := 1; id <= 10; id++ {
+		bus.Publish(lotEvent(id))
+	}
+
+	shutdown(t, bus)
+
+	assert.Len(t, recorder.events, 10)
+	assert.ErrorIs(t, bus.Publish(lotEvent(11)), Exceptions.ErrEventBusClosed)
+	_, err := bus.Subscribe(Implementations.NotifiableHandler(recorder), Implementations.SubscriberOptions{})
+	assert.ErrorIs(t, err, Exceptions.ErrEventBusClosed)
+}
@@ -0,0 +1,137 @@
+package Tests
+
+import (
+	"ParkingLot_go/Enums"
+	"ParkingLot_go/Exceptions"
+	"ParkingLot_go/Implementations"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type eventRecorder struct {
+	events []Implementations.Event
+}
+
+func (recorder *eventRecorder) Notify(event Implementations.Event) {
+	recorder.events = append(recorder.events, event)
+}
+
+func (recorder *eventRecorder) types() []Implementations.EventType {
+	types := []Implementations.EventType{}
+	for _, event := range recorder.events {
+		types = append(types, event.Type)
+	}
+	return types
+}
+
+func TestSubscriberReceivesEveryEventWithDetails(t *testing.T) {
+	clock := newManualClock()
+	owner := Implementations.OwnerConstruct()
+	owner.Clock = clock
+	parkingLot := owner.CreateParkingLot(1)
+	attendent := Implementations.AttendentConstructDefault()
+	attendent.Principal = Implementations.PrincipalConstruct("ravi", Implementations.RoleAttendent)
+	owner.AssignParkingLotToAttendent(attendent, parkingLot)
+	recorder := &eventRecorder{}
+	parkingLot.Subscribe(recorder)
+
+	ticket, _ := attendent.Park(&Implementations.Car{RegistrationNumber: "AP-1", Color: Enums.RED})
+	attendent.Unpark(ticket)
+
+	assert.Equal(t, []Implementations.EventType{
+		Implementations.CarParked,
+		Implementations.LotFull,
+		Implementations.CarUnparked,
+		Implementations.LotAvailable,
+	}, recorder.types())
+	parked := recorder.events[0]
+	assert.Equal(t, clock.Now(), parked.At)
+	assert.Equal(t, parkingLot.GetParkingLotId(), parked.ParkingLotId)
+	assert.Equal(t, 1, parked.Slot.SlotNumber)
+	assert.Equal(t, "AP-1", parked.Vehicle.RegistrationNumber)
+	assert.Equal(t, ticket.GetTicketId(), parked.TicketId)
+	assert.Equal(t, "ravi", parked.Actor)
+	assert.Equal(t, "AP-1", recorder.events[2].Vehicle.RegistrationNumber)
+}
+
+func TestSubscriberReceivesOnlyChosenEventTypes(t *testing.T) {
+	owner := Implementations.OwnerConstruct()
+	parkingLot := owner.CreateParkingLot(1)
+	received := []Implementations.EventType{}
+	parkingLot.Subscribe(Implementations.NotifiableFunc(func(event Implementations.Event) {
+		received = append(received, event.Type)
+	}), Implementations.LotFull)
+
+	ticket, _ := parkingLot.Park(&Implementations.Car{RegistrationNumber: "AP-1", Color: Enums.RED})
+	parkingLot.Unpark(ticket)
+
+	assert.Equal(t, []Implementations.EventType{Implementations.LotFull}, received)
+}
+
+func TestFailedParkPublishesNothing(t *testing.T) {
+	owner := Implementations.OwnerConstruct()
+	parkingLot := owner.CreateParkingLot(2)
+	car := &Implementations.Car{RegistrationNumber: "AP-1", Color: Enums.RED}
+	parkingLot.Park(car)
+	recorder := &eventRecorder{}
+	parkingLot.Subscribe(recorder)
+
+	_, err := parkingLot.Park(car)
+
+	assert.ErrorIs(t, err, Exceptions.ErrCarAlreadyParked)
+	assert.Empty(t, recorder.events)
+}
+
+func TestSlotOutOfServiceIsSkippedAndAnnounced(t *testing.T) {
+	owner := Implementations.OwnerConstruct()
+	parkingLot := owner.CreateParkingLot(2)
+	recorder := &eventRecorder{}
+	parkingLot.Subscribe(recorder, Implementations.SlotOutOfService, Implementations.SlotInService, Implementations.LotFull)
+
+	assert.NoError(t, owner.TakeSlotOutOfService(parkingLot, 1))
+	ticket, err := parkingLot.Park(&Implementations.Car{RegistrationNumber: "AP-1", Color: Enums.RED})
+	assert.NoError(t, err)
+	address, _ := parkingLot.SlotAddressOf(ticket)
+
+	assert.Equal(t, 2, address.SlotNumber)
+	assert.True(t, parkingLot.IsFull())
+	assert.Equal(t, 1, parkingLot.Capacity())
+	assert.NoError(t, owner.ReturnSlotToService(parkingLot, 1))
+	assert.False(t, parkingLot.IsFull())
+	assert.Equal(t, []Implementations.EventType{
+		Implementations.SlotOutOfService,
+		Implementations.LotFull,
+		Implementations.SlotInService,
+	}, recorder.types())
+}
+
+func TestOccupiedOrUnknownSlotCannotBeTakenOutOfService(t *testing.T) {
+	owner := Implementations.OwnerConstruct()
+	parkingLot := owner.CreateParkingLot(1)
+	parkingLot.Park(&Implementations.Car{RegistrationNumber: "AP-1", Color: Enums.RED})
+
+	assert.ErrorIs(t, owner.TakeSlotOutOfService(parkingLot, 1), Exceptions.ErrSlotIsOccupied)
+	assert.ErrorIs(t, owner.TakeSlotOutOfService(parkingLot, 2), Exceptions.ErrSlotNotFound)
+}
+
+func TestVoidedTicketReleasesCarAndClearsAttendent(t *testing.T) {
+	owner := Implementations.OwnerConstruct()
+	parkingLot := owner.CreateParkingLot(1)
+	attendent := Implementations.AttendentConstructDefault()
+	owner.AssignParkingLotToAttendent(attendent, parkingLot)
+	recorder := &eventRecorder{}
+	parkingLot.Subscribe(recorder, Implementations.TicketVoided)
+	ticket, _ := attendent.Park(&Implementations.Car{RegistrationNumber: "AP-1", Color: Enums.RED})
+
+	car, err := owner.VoidTicket(parkingLot, ticket)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "AP-1", car.RegistrationNumber)
+	assert.Equal(t, 0, parkingLot.CountParkedCars())
+	assert.False(t, attendent.HoldsTicket(ticket))
+	assert.NoError(t, attendent.Validate())
+	assert.Equal(t, ticket.GetTicketId(), recorder.events[0].TicketId)
+	_, err = attendent.Unpark(ticket)
+	assert.ErrorIs(t, err, Exceptions.ErrCarNotFound)
+}
@@ -0,0 +1,166 @@
+package Tests
+
+import (
+	"bytes"
+	"crypto/tls"
+	"encoding/base64"
+	"net"
+	"net/http/httptest"
+	"net/textproto"
+	"strings"
+	"sync"
+	"testing"
+)
+
+type fakeMail struct {
+	from          string
+	to            []string
+	data          string
+	overTLS       bool
+	authenticated bool
+}
+
+// fakeSMTPServer speaks just enough SMTP for net/smtp: EHLO, STARTTLS,
+// AUTH PLAIN, MAIL, RCPT, DATA, RSET, NOOP and QUIT.
+type fakeSMTPServer struct {
+	listener    net.Listener
+	tlsConfig   *tls.Config
+	username    string
+	password    string
+	mutex       sync.Mutex
+	mails       []fakeMail
+	connections int
+	failData    int
+}
+
+func startFakeSMTPServer(t *testing.T, withTLS bool, username string, password string) *fakeSMTPServer {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	server := &fakeSMTPServer{listener: listener, username: username, password: password}
+	if withTLS {
+		certificateSource := httptest.NewUnstartedServer(nil)
+		certificateSource.StartTLS()
+		server.tlsConfig = &tls.Config{Certificates: certificateSource.TLS.Certificates}
+		certificateSource.Close()
+	}
+	go server.serve()
+	t.Cleanup(func() { listener.Close() })
+	return server
+}
+
+func (server *fakeSMTPServer) port() int {
+	return server.listener.Addr().(*net.TCPAddr).Port
+}
+
+func (server *fakeSMTPServer) failNextData(times int) {
+	server.mutex.Lock()
+	defer server.mutex.Unlock()
+	server.failData = times
+}
+
+func (server *fakeSMTPServer) received() []fakeMail {
+	server.mutex.Lock()
+	defer server.mutex.Unlock()
+	return append([]fakeMail{}, server.mails...)
+}
+
+func (server *fakeSMTPServer) connectionCount() int {
+	server.mutex.Lock()
+	defer server.mutex.Unlock()
+	return server.connections
+}
+
+func (server *fakeSMTPServer) serve() {
+	for {
+		conn, err := server.listener.Accept()
+		if err != nil {
+			return
+		}
+		server.mutex.Lock()
+		server.connections++
+		server.mutex.Unlock()
+		go server.handle(conn)
+	}
+}
+
+func (server *fakeSMTPServer) handle(conn net.Conn) {
+	defer func() { conn.Close() }()
+	text := textproto.NewConn(conn)
+	text.PrintfLine("220 fake ESMTP ready")
+	mail := fakeMail{}
+	overTLS, authenticated := false, false
+	for {
+		line, err := text.ReadLine()
+		if err != nil {
+			return
+		}
+		verb, argument, _ := strings.Cut(line, " ")
+		switch strings.ToUpper(verb) {
+		case "EHLO", "HELO":
+			text.PrintfLine("250-fake")
+			if server.tlsConfig != nil && !overTLS {
+				text.PrintfLine("250-STARTTLS")
+			}
+			if server.username != "" {
+				text.PrintfLine("250-AUTH PLAIN")
+			}
+			text.PrintfLine("250 OK")
+		case "STARTTLS":
+			text.PrintfLine("220 ready to start TLS")
+			tlsConn := tls.Server(conn, server.tlsConfig)
+			if tlsConn.Handshake() != nil {
+				return
+			}
+			conn, text, overTLS = tlsConn, textproto.NewConn(tlsConn), true
+		case "AUTH":
+			_, encoded, _ := strings.Cut(argument, " ")
+			credentials, _ := base64.StdEncoding.DecodeString(encoded)
+			if bytes.Equal(credentials, []byte("\x00"+server.username+"\x00"+server.password)) {
+				authenticated = true
+				text.PrintfLine("235 authenticated")
+			} else {
+				text.PrintfLine("535 authentication failed")
+			}
+		case "MAIL":
+			if server.username != "" && !authenticated {
+				text.PrintfLine("530 authentication required")
+				continue
+			}
+			mail = fakeMail{from: strings.Trim(strings.TrimPrefix(argument, "FROM:"), "<>"), overTLS: overTLS, authenticated: authenticated}
+			text.PrintfLine("250 OK")
+		case "RCPT":
+			mail.to = append(mail.to, strings.Trim(strings.TrimPrefix(argument, "TO:"), "<>"))
+			text.PrintfLine("250 OK")
+		case "DATA":
+			server.mutex.Lock()
+			failing := server.failData > 0
+			if failing {
+				server.failData--
+			}
+			server.mutex.Unlock()
+			if failing {
+				text.PrintfLine("451 try again later")
+				continue
+			}
+			text.PrintfLine("354 go ahead")
+			data, err := text.ReadDotBytes()
+			if err != nil {
+				return
+			}
+			mail.data = string(data)
+			server.mutex.Lock()
+			server.mails = append(server.mails, mail)
+			server.mutex.Unlock()
+			text.PrintfLine("250 queued")
+		case "RSET", "NOOP":
+			text.PrintfLine("250 OK")
+		case "QUIT":
+			text.PrintfLine("221 bye")
+			return
+		default:
+			text.PrintfLine("502 not implemented")
+		}
+	}
+}
@@ -0,0 +1,19 @@
+package Tests
+
+import "time"
+
+type manualClock struct {
+	now time.Time
+}
+
+func newManualClock() *manualClock {
+	return &manualClock{now: time.Date(2024, time.March, 4, 9, 0, 0, 0, time.UTC)}
+}
+
+func (clock *manualClock) Now() time.Time {
+	return clock.now
+}
+
+func (clock *manualClock) Advance(duration time.Duration) {
+	clock.now = clock.now.Add(duration)
+}
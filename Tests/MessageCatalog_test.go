@@ -0,0 +1,138 @@
+package Tests
+
+import (
+	"ParkingLot_go/Enums"
+	"ParkingLot_go/Implementations"
+	"encoding/json"
+	"mime"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func catalogEvent() Implementations.Event {
+	return Implementations.Event{
+		Type:             Implementations.CarParked,
+		At:               time.Date(2024, time.March, 4, 9, 0, 0, 0, time.UTC),
+		ParkingLotId:     7,
+		ParkingLotName:   "Central Garage",
+		Slot:             Implementations.SlotAddress{ParkingLotId: 7, SlotNumber: 3},
+		Vehicle:          &Implementations.Car{RegistrationNumber: "AP-1", Color: Enums.RED},
+		OccupancyPercent: 80,
+	}
+}
+
+func TestCatalogRendersEachLocale(t *testing.T) {
+	catalog := Implementations.MessageCatalogConstruct()
+	event := catalogEvent()
+
+	english, _ := catalog.Render(event, Implementations.Recipient{Locale: Implementations.English})
+	hindi, _ := catalog.Render(event, Implementations.Recipient{Locale: Implementations.Hindi})
+	spanish, _ := catalog.Render(event, Implementations.Recipient{Locale: Implementations.Spanish})
+
+	assert.Equal(t, "Car AP-1 parked at Central Garage, slot 3 at 2024-03-04 09:00 UTC.", english)
+	assert.Equal(t, "गाड़ी AP-1 Central Garage, स्लॉट 3 में पार्क हुई। समय: 2024-03-04 09:00 UTC", hindi)
+	assert.Equal(t, "El vehículo AP-1 se estacionó en Central Garage, plaza 3. Hora: 2024-03-04 09:00 UTC.", spanish)
+}
+
+func TestCatalogUsesRecipientTimeZoneAndOccupancy(t *testing.T) {
+	kolkata := time.FixedZone("IST", 5*60*60+30*60)
+	event := catalogEvent()
+	event.Type = Implementations.LotFull
+	event.ParkingLotName = ""
+
+	message, _ := Implementations.DefaultCatalog.Render(event, Implementations.Recipient{Locale: Implementations.Hindi, Location: kolkata})
+
+	assert.Equal(t, "पार्किंग स्थल 7 भर गया है (80%)। समय: 2024-03-04 14:30 IST", message)
+}
+
+func TestRegisteredLocaleFallsBackToEnglishLotAndStageNames(t *testing.T) {
+	catalog := Implementations.MessageCatalogConstruct()
+	assert.NoError(t, catalog.Register("fr", Implementations.LotFull, "{{.LotName}} est complet."))
+	assert.NoError(t, catalog.Register("fr", Implementations.VehicleOverstayed, "{{.Registration}}: {{.Stage}}"))
+	event := catalogEvent()
+	event.Type = Implementations.LotFull
+	event.ParkingLotName = ""
+
+	full, _ := catalog.Render(event, Implementations.Recipient{Locale: "fr"})
+	event.Type = Implementations.VehicleOverstayed
+	event.Stage = Implementations.OverstayWarning
+	overstayed, _ := catalog.Render(event, Implementations.Recipient{Locale: "fr"})
+
+	assert.Equal(t, "Parking lot 7 est complet.", full)
+	assert.Equal(t, "AP-1: warning", overstayed)
+}
+
+func TestDigestRendersInTheRecipientLocaleAndTimeZone(t *testing.T) {
+	kolkata := time.FixedZone("IST", 5*60*60+30*60)
+	from := time.Date(2024, time.March, 4, 9, 0, 0, 0, time.UTC)
+	digest := Implementations.Digest{
+		From: from,
+		To:   from.Add(time.Hour),
+		Lots: []Implementations.LotDigest{
+			{ParkingLotId: 7, ParkingLotName: "Central Garage", TimesFilled: 2, PeakOccupancy: 100, TimeFull: 45 * time.Minute},
+			{ParkingLotId: 8, TimesFilled: 0, PeakOccupancy: 50},
+		},
+	}
+
+	spanish, _ := Implementations.DefaultCatalog.RenderDigest(digest, Implementations.Recipient{Locale: Implementations.Spanish, Location: kolkata})
+	french, _ := Implementations.DefaultCatalog.RenderDigest(digest, Implementations.Recipient{Locale: "fr"})
+
+	assert.Equal(t, "Del 2024-03-04 14:30 IST al 2024-03-04 15:30 IST; Central Garage se llenó 2 veces, máximo 100%, lleno durante 45m0s; Estacionamiento 8 se llenó 0 veces, máximo 50%, lleno durante 0s", spanish)
+	assert.Equal(t, "2024-03-04 09:00 UTC to 2024-03-04 10:00 UTC; Central Garage filled 2 times, peak 100%, full for 45m0s; Parking lot 8 filled 0 times, peak 50%, full for 0s", french)
+}
+
+func TestCatalogFallsBackToEnglish(t *testing.T) {
+	catalog := Implementations.MessageCatalogConstruct()
+	event := catalogEvent()
+	event.Type = Implementations.LotAvailable
+
+	message, _ := catalog.Render(event, Implementations.Recipient{Locale: "fr"})
+
+	assert.True(t, strings.HasPrefix(message, "Central Garage has available slots"))
+}
+
+func TestCatalogTemplatesCanBeReplaced(t *testing.T) {
+	catalog := Implementations.MessageCatalogConstruct()
+	assert.NoError(t, catalog.Register(Implementations.Spanish, Implementations.CarParked, "{{.Registration}} → {{.LotName}}"))
+	assert.Error(t, catalog.Register(Implementations.Spanish, Implementations.CarParked, "{{.Registration"))
+
+	message, _ := catalog.Render(catalogEvent(), Implementations.Recipient{Locale: Implementations.Spanish})
+
+	assert.Equal(t, "AP-1 → Central Garage", message)
+}
+
+func TestEmailAndWebhookRenderInTheRecipientsLocale(t *testing.T) {
+	server := startFakeSMTPServer(t, false, "", "")
+	notifier := Implementations.EmailNotifierConstruct(Implementations.SMTPConfig{Host: "127.0.0.1", Port: server.port()}, "lots@example.com", "dueño@example.com")
+	defer notifier.Close()
+	notifier.Recipient = Implementations.Recipient{Locale: Implementations.Spanish}
+	receiver := &webhookReceiver{}
+	webhookServer := httptest.NewServer(receiver)
+	defer webhookServer.Close()
+	webhook := Implementations.WebhookConstruct("s3cret", webhookServer.URL)
+	webhook.Recipient = Implementations.Recipient{Locale: Implementations.Hindi}
+	bus := webhookBus(webhook, 1)
+	bus.Subscribe(notifier, Implementations.SubscriberOptions{Name: "email"})
+	owner := Implementations.OwnerConstruct()
+	parkingLot := owner.CreateParkingLot(1)
+	parkingLot.Name = "Central Garage"
+	parkingLot.Subscribe(bus, Implementations.LotFull)
+
+	parkingLot.Park(&Implementations.Car{RegistrationNumber: "AP-1", Color: Enums.RED})
+	shutdown(t, bus)
+
+	data := server.received()[0].data
+	subjectLine := strings.SplitN(strings.SplitN(data, "Subject: ", 2)[1], "\n", 2)[0]
+	subject, err := new(mime.WordDecoder).DecodeHeader(strings.TrimSpace(subjectLine))
+	assert.NoError(t, err)
+	assert.True(t, strings.HasPrefix(subject, "Central Garage está lleno (100%)"))
+	var payload struct {
+		Message string `json:"message"`
+	}
+	json.Unmarshal(receiver.requests[0].body, &payload)
+	assert.True(t, strings.HasPrefix(payload.Message, "Central Garage भर गया है (100%)"))
+}
@@ -0,0 +1,168 @@
+package Tests
+
+import (
+	"ParkingLot_go/Enums"
+	"ParkingLot_go/Exceptions"
+	"ParkingLot_go/Implementations"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func parkCars(t *testing.T, parkingLot *Implementations.ParkingLot, count int) []*Implementations.Ticket {
+	tickets := []*Implementations.Ticket{}
+	for i := 0; i < count; i++ {
+		ticket, _ := parkingLot.Park(&Implementations.Car{RegistrationNumber: fmt.Sprintf("AP-%d-%d", parkingLot.CountParkedCars(), i), Color: Enums.RED})
+		tickets = append(tickets, ticket)
+	}
+	return tickets
+}
+
+func TestLotThresholdsAlertOnceEachWithOccupancy(t *testing.T) {
+	owner := Implementations.OwnerConstruct()
+	parkingLot := owner.CreateParkingLot(10)
+	assert.NoError(t, parkingLot.SetOccupancyThresholds(
+		Implementations.OccupancyThreshold{Percent: 80},
+		Implementations.OccupancyThreshold{Percent: 95},
+	))
+	recorder := &eventRecorder{}
+	parkingLot.Subscribe(recorder, Implementations.OccupancyAboveThreshold, Implementations.OccupancyBelowThreshold)
+
+	parkCars(t, parkingLot, 10)
+
+	assert.Len(t, recorder.events, 2)
+	assert.Equal(t, 80.0, recorder.events[0].Threshold)
+	assert.Equal(t, 80.0, recorder.events[0].OccupancyPercent)
+	assert.Equal(t, 95.0, recorder.events[1].Threshold)
+	assert.Equal(t, 100.0, recorder.events[1].OccupancyPercent)
+}
+
+func TestHysteresisStopsChurnAtTheThreshold(t *testing.T) {
+	owner := Implementations.OwnerConstruct()
+	parkingLot := owner.CreateParkingLot(10)
+	parkingLot.SetOccupancyThresholds(Implementations.OccupancyThreshold{Percent: 80, Hysteresis: 20})
+	recorder := &eventRecorder{}
+	parkingLot.Subscribe(recorder, Implementations.OccupancyAboveThreshold, Implementations.OccupancyBelowThreshold)
+	tickets := parkCars(t, parkingLot, 8)
+
+	for i := 0; i < 3; i++ {
+		parkingLot.Unpark(tickets[i])
+		tickets[i] = parkCars(t, parkingLot, 1)[0]
+	}
+	assert.Equal(t, []Implementations.EventType{Implementations.OccupancyAboveThreshold}, recorder.types())
+
+	for i := 0; i < 3; i++ {
+		parkingLot.Unpark(tickets[i])
+	}
+	assert.Equal(t, []Implementations.EventType{
+		Implementations.OccupancyAboveThreshold,
+		Implementations.OccupancyBelowThreshold,
+	}, recorder.types())
+}
+
+func TestMinIntervalHoldsBackRepeatAlerts(t *testing.T) {
+	clock := newManualClock()
+	owner := Implementations.OwnerConstruct()
+	owner.Clock = clock
+	parkingLot := owner.CreateParkingLot(2)
+	recorder := &eventRecorder{}
+	parkingLot.SubscribeThresholds(recorder, Implementations.OccupancyThreshold{Percent: 100, MinInterval: 10 * time.Minute})
+	tickets := parkCars(t, parkingLot, 2)
+
+	parkingLot.Unpark(tickets[0])
+	clock.Advance(time.Minute)
+	tickets[0] = parkCars(t, parkingLot, 1)[0]
+	assert.Equal(t, []Implementations.EventType{
+		Implementations.OccupancyAboveThreshold,
+		Implementations.OccupancyBelowThreshold,
+	}, recorder.types())
+
+	parkingLot.Unpark(tickets[0])
+	clock.Advance(10 * time.Minute)
+	parkCars(t, parkingLot, 1)
+	assert.Len(t, recorder.events, 3)
+	assert.Equal(t, Implementations.OccupancyAboveThreshold, recorder.events[2].Type)
+}
+
+func TestAlertHeldBackByMinIntervalFiresOnceItHasPassed(t *testing.T) {
+	clock := newManualClock()
+	owner := Implementations.OwnerConstruct()
+	owner.Clock = clock
+	parkingLot := owner.CreateParkingLot(4)
+	recorder := &eventRecorder{}
+	parkingLot.SubscribeThresholds(recorder, Implementations.OccupancyThreshold{Percent: 50, MinInterval: 10 * time.Minute})
+	tickets := parkCars(t, parkingLot, 2)
+	parkingLot.Unpark(tickets[0])
+
+	clock.Advance(time.Minute)
+	held := parkCars(t, parkingLot, 1)
+	assert.Len(t, recorder.events, 2)
+
+	clock.Advance(10 * time.Minute)
+	late := parkCars(t, parkingLot, 1)
+	assert.Len(t, recorder.events, 3)
+	assert.Equal(t, Implementations.OccupancyAboveThreshold, recorder.events[2].Type)
+	assert.Equal(t, 75.0, recorder.events[2].OccupancyPercent)
+
+	parkingLot.Unpark(held[0])
+	parkingLot.Unpark(late[0])
+	assert.Len(t, recorder.events, 4)
+	assert.Equal(t, Implementations.OccupancyBelowThreshold, recorder.events[3].Type)
+}
+
+func TestSubscriberThresholdsOnlyReachThatSubscriber(t *testing.T) {
+	owner := Implementations.OwnerConstruct()
+	parkingLot := owner.CreateParkingLot(4)
+	mine := &eventRecorder{}
+	others := &eventRecorder{}
+	parkingLot.Subscribe(others)
+	parkingLot.SubscribeThresholds(mine, Implementations.OccupancyThreshold{Percent: 50})
+
+	parkCars(t, parkingLot, 2)
+
+	assert.Len(t, mine.events, 1)
+	assert.NotContains(t, others.types(), Implementations.OccupancyAboveThreshold)
+}
+
+func TestInvalidThresholdIsRejected(t *testing.T) {
+	owner := Implementations.OwnerConstruct()
+	parkingLot := owner.CreateParkingLot(4)
+
+	err := parkingLot.SetOccupancyThresholds(Implementations.OccupancyThreshold{Percent: 80, Hysteresis: 90})
+	assert.ErrorIs(t, err, Exceptions.ErrInvalidThreshold)
+	_, err = parkingLot.SubscribeThresholds(&eventRecorder{}, Implementations.OccupancyThreshold{Percent: 120})
+	assert.ErrorIs(t, err, Exceptions.ErrInvalidThreshold)
+}
+
+func TestUnsubscribedThresholdsStopAlerting(t *testing.T) {
+	owner := Implementations.OwnerConstruct()
+	parkingLot := owner.CreateParkingLot(4)
+	recorder := &eventRecorder{}
+	subscription, err := parkingLot.SubscribeThresholds(recorder, Implementations.OccupancyThreshold{Percent: 50})
+	assert.NoError(t, err)
+
+	subscription.Unsubscribe()
+	parkCars(t, parkingLot, 2)
+
+	assert.False(t, subscription.IsActive())
+	assert.Empty(t, recorder.events)
+}
+
+func TestSellersThresholdSubscriptionsEndWithTheSale(t *testing.T) {
+	seller := Implementations.OwnerConstruct()
+	buyer := Implementations.OwnerConstruct()
+	parkingLot := seller.CreateParkingLot(4)
+	sellers, _ := parkingLot.SubscribeThresholds(seller, Implementations.OccupancyThreshold{Percent: 50})
+	recorder := &eventRecorder{}
+	others, _ := parkingLot.SubscribeThresholds(recorder, Implementations.OccupancyThreshold{Percent: 50})
+
+	_, err := seller.TransferParkingLot(parkingLot, buyer, Implementations.TransferTerms{Policy: Implementations.RevokeAttendents})
+	assert.NoError(t, err)
+	parkCars(t, parkingLot, 2)
+
+	assert.False(t, sellers.IsActive())
+	assert.True(t, others.IsActive())
+	assert.Equal(t, []Implementations.EventType{Implementations.OccupancyAboveThreshold}, recorder.types())
+}
@@ -0,0 +1,147 @@
+package Tests
+
+import (
+	"ParkingLot_go/Enums"
+	"ParkingLot_go/Implementations"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func overstayLot(t *testing.T, clock *manualClock) (*Implementations.Owner, *Implementations.ParkingLot) {
+	owner := Implementations.OwnerConstruct()
+	owner.Clock = clock
+	parkingLot := owner.CreateParkingLot(2)
+	parkingLot.SetOverstayLimits(Implementations.OverstayLimits{
+		Default:          24 * time.Hour,
+		FinalNoticeAfter: 48 * time.Hour,
+		TowEligibleAfter: 7 * 24 * time.Hour,
+	})
+	return owner, parkingLot
+}
+
+func TestSweeperEscalatesThroughEachStageOnce(t *testing.T) {
+	clock := newManualClock()
+	_, parkingLot := overstayLot(t, clock)
+	sweeper := Implementations.OverstaySweeperConstruct(clock, parkingLot)
+	parkingLot.Park(&Implementations.Car{RegistrationNumber: "AP-1", Color: Enums.RED})
+
+	clock.Advance(23 * time.Hour)
+	assert.Empty(t, sweeper.Sweep())
+
+	clock.Advance(2 * time.Hour)
+	raised := sweeper.Sweep()
+	assert.Len(t, raised, 1)
+	assert.Equal(t, Implementations.OverstayWarning, raised[0].Stage)
+	assert.Equal(t, Implementations.Warning, raised[0].Level())
+	assert.Empty(t, sweeper.Sweep())
+
+	clock.Advance(48 * time.Hour)
+	assert.Equal(t, Implementations.OverstayFinalNotice, sweeper.Sweep()[0].Stage)
+
+	clock.Advance(7 * 24 * time.Hour)
+	raised = sweeper.Sweep()
+	assert.Equal(t, Implementations.TowEligible, raised[0].Stage)
+	assert.Equal(t, Implementations.Critical, raised[0].Level())
+	assert.Equal(t, "AP-1", raised[0].Vehicle.RegistrationNumber)
+	assert.Equal(t, 1, raised[0].Slot.SlotNumber)
+}
+
+func TestSlotTypeLimitOverridesTheLotDefault(t *testing.T) {
+	clock := newManualClock()
+	owner, parkingLot := overstayLot(t, clock)
+	assert.NoError(t, owner.SetSlotType(parkingLot, 2, Enums.ELECTRIC))
+	parkingLot.SetOverstayLimits(Implementations.OverstayLimits{
+		Default:    24 * time.Hour,
+		BySlotType: map[Enums.SlotType]time.Duration{Enums.ELECTRIC: 2 * time.Hour},
+	})
+	sweeper := Implementations.OverstaySweeperConstruct(clock, parkingLot)
+	parkingLot.Park(&Implementations.Car{RegistrationNumber: "AP-1", Color: Enums.RED})
+	parkingLot.Park(&Implementations.Car{RegistrationNumber: "EV-1", Color: Enums.WHITE})
+
+	clock.Advance(3 * time.Hour)
+	raised := sweeper.Sweep()
+
+	assert.Len(t, raised, 1)
+	assert.Equal(t, "EV-1", raised[0].Vehicle.RegistrationNumber)
+}
+
+func TestOverstayReachesOwnerSubscribersAndPoliceman(t *testing.T) {
+	clock := newManualClock()
+	owner, parkingLot := overstayLot(t, clock)
+	ownerFeed := &eventRecorder{}
+	owner.SubscribeAll(ownerFeed, Implementations.EventFilter{EventTypes: []Implementations.EventType{Implementations.VehicleOverstayed}})
+	policeFeed := &eventRecorder{}
+	owner.RegisterNotifiable(parkingLot, policeFeed)
+	sweeper := Implementations.OverstaySweeperConstruct(clock)
+	sweeper.Watch(parkingLot)
+	parkingLot.Park(&Implementations.Car{RegistrationNumber: "AP-1", Color: Enums.RED})
+
+	clock.Advance(25 * time.Hour)
+	sweeper.Sweep()
+
+	assert.Len(t, ownerFeed.events, 1)
+	assert.Equal(t, []Implementations.EventType{Implementations.VehicleOverstayed}, policeFeed.types())
+}
+
+func TestCarThatLeftIsForgottenAndNotRaisedAgain(t *testing.T) {
+	clock := newManualClock()
+	_, parkingLot := overstayLot(t, clock)
+	sweeper := Implementations.OverstaySweeperConstruct(clock, parkingLot)
+	ticket, _ := parkingLot.Park(&Implementations.Car{RegistrationNumber: "AP-1", Color: Enums.RED})
+	clock.Advance(25 * time.Hour)
+	sweeper.Sweep()
+
+	parkingLot.Unpark(ticket)
+	parkingLot.Park(&Implementations.Car{RegistrationNumber: "AP-1", Color: Enums.RED})
+	clock.Advance(time.Hour)
+
+	assert.Empty(t, sweeper.Sweep())
+}
+
+func TestBackgroundSweeperRunsUntilStopped(t *testing.T) {
+	clock := newManualClock()
+	_, parkingLot := overstayLot(t, clock)
+	parkingLot.Park(&Implementations.Car{RegistrationNumber: "AP-1", Color: Enums.RED})
+	clock.Advance(25 * time.Hour)
+	raised := make(chan Implementations.Event, 1)
+	parkingLot.Subscribe(Implementations.NotifiableFunc(func(event Implementations.Event) {
+		raised <- event
+	}), Implementations.VehicleOverstayed)
+	sweeper := Implementations.OverstaySweeperConstruct(clock, parkingLot)
+
+	sweeper.Start(time.Millisecond)
+	select {
+	case <-sweeper.Due():
+		sweeper.Sweep()
+	case <-time.After(time.Second):
+		t.Fatal("sweeper never ran")
+	}
+	sweeper.Stop()
+
+	event := <-raised
+	assert.Equal(t, Implementations.OverstayWarning, event.Stage)
+}
+
+func TestParkingWhileBackgroundSweeperRunsDoesNotRace(t *testing.T) {
+	clock := newManualClock()
+	_, parkingLot := overstayLot(t, clock)
+	sweeper := Implementations.OverstaySweeperConstruct(clock, parkingLot)
+	sweeper.Start(time.Microsecond)
+	defer sweeper.Stop()
+
+	raised := []Implementations.Event{}
+	for i := 0; i < 2; i++ {
+		ticket, err := parkingLot.Park(&Implementations.Car{RegistrationNumber: fmt.Sprintf("AP-%d", i), Color: Enums.RED})
+		assert.NoError(t, err)
+		clock.Advance(25 * time.Hour)
+		<-sweeper.Due()
+		raised = append(raised, sweeper.Sweep()...)
+		_, err = parkingLot.Unpark(ticket)
+		assert.NoError(t, err)
+	}
+
+	assert.Len(t, raised, 2)
+}
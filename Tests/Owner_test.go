@@ -22,6 +22,15 @@ func TestCreatingParkingLotWithZeroTotalSlots(t *testing.T) {
 	})
 }
 
+func TestOwnerCreateParkingLotCheckedReportsInvalidSize(t *testing.T) {
+	owner := Implementations.OwnerConstruct()
+
+	parkingLot, err := owner.CreateParkingLotChecked(0)
+	assert.Nil(t, parkingLot)
+	assert.Equal(t, Exceptions.ErrCannotCreateParkingLotException, err)
+	assert.Empty(t, owner.OwnerParkingLots)
+}
+
 func TestExceptionWhenOwnerAssignNotOwnedParkingLot(t *testing.T) {
 	owner := Implementations.OwnerConstruct()
 	otherOwner := Implementations.OwnerConstruct()
@@ -29,7 +38,7 @@ func TestExceptionWhenOwnerAssignNotOwnedParkingLot(t *testing.T) {
 	firstParkingLot := otherOwner.CreateParkingLot(3)
 
 	err := owner.AssignParkingLotToAttendent(attendent, firstParkingLot)
-	assert.EqualError(t, err, "this parking lot is not owned by the owner")
+	assert.EqualError(t, err, Exceptions.ErrParkingLotNotOwned.WithLot(firstParkingLot.GetParkingLotId()).Error())
 }
 
 func TestOwnerAssignParkingLotToAttendent(t *testing.T) {
@@ -47,7 +56,7 @@ func TestExceptionAssignParkingLotIsNotOwnedByOwner(t *testing.T) {
 	firstParkingLot := secondOwner.CreateParkingLot(3)
 
 	err := owner.AssignParkingLotToAttendent(attendent, firstParkingLot)
-	assert.EqualError(t, err, "this parking lot is not owned by the owner")
+	assert.EqualError(t, err, Exceptions.ErrParkingLotNotOwned.WithLot(firstParkingLot.GetParkingLotId()).Error())
 }
 
 func TestOwnerAssignMultipleParkingLotToSingleAttendent(t *testing.T) {
@@ -80,7 +89,7 @@ func TestSingleAttendentCannotHaveMultipleOwners(t *testing.T) {
 
 	assert.NoError(t, owner.AssignParkingLotToAttendent(attendent, firstParkingLot))
 	err := otherOwner.AssignParkingLotToAttendent(attendent, secondParkingLot)
-	assert.EqualError(t, err, "this parking lot is not owned by the owner")
+	assert.EqualError(t, err, Exceptions.ErrParkingLotNotOwned.WithLot(secondParkingLot.GetParkingLotId()).Error())
 }
 
 func TestOwnerAssignParkingLotToSelf(t *testing.T) {
@@ -153,7 +162,7 @@ func TestOwnerUnParkingCarParkedInNonAssignedParkingLot(t *testing.T) {
 	assert.NoError(t, err)
 
 	_, err = owner.Unpark(ticket)
-	assert.EqualError(t, err, "car not found")
+	assert.EqualError(t, err, Exceptions.ErrCarNotFound.WithTicket(ticket.GetTicketId()).Error())
 }
 
 func TestOwnerNotifiedWhenParkingLotFull(t *testing.T) {
@@ -166,7 +175,7 @@ func TestOwnerNotifiedWhenParkingLotFull(t *testing.T) {
 	assert.NoError(t, err)
 
 	_, err = parkingLot.Park(&Implementations.Car{LicensePlate: "AP-5678", Color: Enums.BLUE})
-	assert.Equal(t, Exceptions.ErrParkingLotIsFull, err) // Check for the specific error
+	assert.ErrorIs(t, err, Exceptions.ErrParkingLotIsFull) // Check for the specific error
 }
 
 func TestOwnerNotifyFullAllParkingLotsAreFull(t *testing.T) {
@@ -185,9 +194,9 @@ func TestOwnerNotifyFullAllParkingLotsAreFull(t *testing.T) {
 	assert.NoError(t, err)
 
 	_, err = firstLot.Park(&Implementations.Car{LicensePlate: "AP-9999", Color: Enums.GREEN})
-	assert.EqualError(t, err, "parking lot is full")
+	assert.EqualError(t, err, Exceptions.ErrParkingLotIsFull.WithLot(firstLot.GetParkingLotId()).Error())
 	_, err = secondLot.Park(&Implementations.Car{LicensePlate: "AP-9998", Color: Enums.YELLOW})
-	assert.EqualError(t, err, "parking lot is full")
+	assert.EqualError(t, err, Exceptions.ErrParkingLotIsFull.WithLot(secondLot.GetParkingLotId()).Error())
 }
 
 func TestOwnerNotifyFullSomeParkingLotsAreFull(t *testing.T) {
@@ -206,7 +215,7 @@ func TestOwnerNotifyFullSomeParkingLotsAreFull(t *testing.T) {
 	assert.NoError(t, err)
 
 	_, err = firstLot.Park(&Implementations.Car{LicensePlate: "AP-9999", Color: Enums.GREEN})
-	assert.EqualError(t, err, "parking lot is full")
+	assert.EqualError(t, err, Exceptions.ErrParkingLotIsFull.WithLot(firstLot.GetParkingLotId()).Error())
 }
 
 func TestNotifyFullWhenParkingLotIsFull(t *testing.T) {
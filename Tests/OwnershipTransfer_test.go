@@ -0,0 +1,101 @@
+package Tests
+
+import (
+	"ParkingLot_go/Enums"
+	"ParkingLot_go/Exceptions"
+	"ParkingLot_go/Implementations"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTransferMovesLotBetweenOwners(t *testing.T) {
+	seller := Implementations.OwnerConstruct()
+	buyer := Implementations.OwnerConstruct()
+	parkingLot := seller.CreateParkingLot(2)
+	ticket, _ := parkingLot.Park(&Implementations.Car{RegistrationNumber: "AP-1234", Color: Enums.RED})
+
+	transfer, err := seller.TransferParkingLot(parkingLot, buyer, Implementations.TransferTerms{Policy: Implementations.RevokeAttendents})
+
+	assert.NoError(t, err)
+	assert.Equal(t, buyer, parkingLot.Owner)
+	assert.NotContains(t, seller.OwnerParkingLots, parkingLot)
+	assert.Contains(t, buyer.OwnerParkingLots, parkingLot)
+	assert.Equal(t, []string{ticket.GetTicketId()}, transfer.ActiveTicketIds)
+	assert.Equal(t, []*Implementations.OwnershipTransfer{transfer}, parkingLot.OwnershipHistory())
+
+	car, err := parkingLot.Unpark(ticket)
+	assert.NoError(t, err)
+	assert.Equal(t, "AP-1234", car.RegistrationNumber)
+}
+
+func TestTransferRevokesSellersAttendents(t *testing.T) {
+	seller := Implementations.OwnerConstruct()
+	buyer := Implementations.OwnerConstruct()
+	parkingLot := seller.CreateParkingLot(2)
+	attendent := Implementations.AttendentConstructDefault()
+	seller.AssignParkingLotToAttendent(attendent, parkingLot)
+	ticket, _ := attendent.Park(&Implementations.Car{RegistrationNumber: "AP-1234", Color: Enums.RED})
+
+	transfer, err := seller.TransferParkingLot(parkingLot, buyer, Implementations.TransferTerms{Policy: Implementations.RevokeAttendents})
+
+	assert.NoError(t, err)
+	assert.Equal(t, []*Implementations.Attendent{attendent}, transfer.RevokedAttendents)
+	assert.Empty(t, attendent.AssignedParkingLots)
+	assert.Len(t, seller.Revocations, 1)
+	car, err := buyer.DispatchUnpark(ticket)
+	assert.NoError(t, err)
+	assert.Equal(t, "AP-1234", car.RegistrationNumber)
+}
+
+func TestTransferRecontractsSellersAttendents(t *testing.T) {
+	seller := Implementations.OwnerConstruct()
+	buyer := Implementations.OwnerConstruct()
+	parkingLot := seller.CreateParkingLot(2)
+	attendent := Implementations.AttendentConstructDefault()
+	seller.AssignParkingLotToAttendent(attendent, parkingLot)
+	ticket, _ := attendent.Park(&Implementations.Car{RegistrationNumber: "AP-1234", Color: Enums.RED})
+
+	transfer, err := seller.TransferParkingLot(parkingLot, buyer, Implementations.TransferTerms{
+		Policy:        Implementations.RecontractAttendents,
+		ContractUntil: time.Now().Add(24 * time.Hour),
+		FeePerPark:    300,
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, []*Implementations.Attendent{attendent}, transfer.RecontractedAttendents)
+	assert.Contains(t, buyer.Attendents, attendent)
+	assert.True(t, attendent.HoldsTicket(ticket))
+	_, err = attendent.ParkFor(buyer, &Implementations.Car{RegistrationNumber: "AP-5678", Color: Enums.BLUE})
+	assert.NoError(t, err)
+	activity := attendent.ActivityFor(buyer)
+	assert.Equal(t, int64(300), activity.Revenue)
+}
+
+func TestTransferRequiresOwnershipAndDifferentBuyer(t *testing.T) {
+	seller := Implementations.OwnerConstruct()
+	buyer := Implementations.OwnerConstruct()
+	parkingLot := seller.CreateParkingLot(2)
+
+	_, err := buyer.TransferParkingLot(parkingLot, seller, Implementations.TransferTerms{})
+	assert.ErrorIs(t, err, Exceptions.ErrParkingLotNotOwned)
+	_, err = seller.TransferParkingLot(parkingLot, seller, Implementations.TransferTerms{})
+	assert.ErrorIs(t, err, Exceptions.ErrInvalidTransfer)
+	_, err = seller.TransferParkingLot(parkingLot, buyer, Implementations.TransferTerms{Policy: Implementations.RecontractAttendents})
+	assert.ErrorIs(t, err, Exceptions.ErrInvalidContract)
+}
+
+func TestBuyerOperatesTransferredLot(t *testing.T) {
+	seller := Implementations.OwnerConstruct()
+	buyer := Implementations.OwnerConstruct()
+	parkingLot := seller.CreateParkingLot(1)
+	seller.RegisterNotifiable(parkingLot, Implementations.PolicemanConstruct())
+
+	seller.TransferParkingLot(parkingLot, buyer, Implementations.TransferTerms{Policy: Implementations.RevokeAttendents})
+
+	assert.NoError(t, buyer.AssignParkingLotToSelf(parkingLot))
+	_, err := buyer.Park(&Implementations.Car{RegistrationNumber: "AP-1234", Color: Enums.RED})
+	assert.NoError(t, err)
+	assert.True(t, parkingLot.IsFull())
+}
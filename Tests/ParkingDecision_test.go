@@ -0,0 +1,89 @@
+package Tests
+
+import (
+	"ParkingLot_go/Enums"
+	"ParkingLot_go/Exceptions"
+	"ParkingLot_go/Implementations"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAttendentExplainsSelectedLotAndSlot(t *testing.T) {
+	owner := Implementations.OwnerConstruct()
+	parkingLot := owner.CreateParkingLot(2)
+	attendent := Implementations.AttendentConstructDefault()
+	owner.AssignParkingLotToAttendent(attendent, parkingLot)
+	car := &Implementations.Car{RegistrationNumber: "AP-1234", Color: Enums.RED}
+
+	ticket, err := attendent.Park(car)
+	assert.NoError(t, err)
+
+	decision, err := attendent.Explain(ticket.GetTicketId())
+	assert.NoError(t, err)
+	assert.Equal(t, "NormalNextLotStrategy", decision.Strategy)
+	assert.Equal(t, parkingLot.GetParkingLotId(), decision.SelectedParkingLotId)
+	assert.Equal(t, Implementations.SlotAddress{ParkingLotId: parkingLot.GetParkingLotId(), SlotNumber: 1}, decision.Slot)
+	assert.Equal(t, "nearest free slot", decision.SlotReason)
+}
+
+func TestExplanationListsFullAndFilteredLots(t *testing.T) {
+	owner := Implementations.OwnerConstruct()
+	firstLot := owner.CreateParkingLot(1)
+	secondLot := owner.CreateParkingLot(2)
+	thirdLot := owner.CreateParkingLot(2)
+	attendent := Implementations.AttendentConstructDefault()
+	owner.AssignParkingLotToAttendent(attendent, firstLot)
+	owner.AssignParkingLotToAttendent(attendent, secondLot)
+	owner.AssignParkingLotToAttendent(attendent, thirdLot)
+
+	attendent.Park(&Implementations.Car{RegistrationNumber: "AP-1234", Color: Enums.RED})
+	_, decision, err := attendent.ParkWithExplanation(&Implementations.Car{RegistrationNumber: "AP-5678", Color: Enums.BLUE})
+	assert.NoError(t, err)
+
+	first, _ := decision.Candidate(firstLot.GetParkingLotId())
+	second, _ := decision.Candidate(secondLot.GetParkingLotId())
+	third, _ := decision.Candidate(thirdLot.GetParkingLotId())
+	assert.Equal(t, Implementations.CandidateFull, first.Outcome)
+	assert.Equal(t, Implementations.CandidateSelected, second.Outcome)
+	assert.Equal(t, Implementations.CandidateFiltered, third.Outcome)
+}
+
+func TestSmartAttendentExplanationNamesStrategy(t *testing.T) {
+	owner := Implementations.OwnerConstruct()
+	firstLot := owner.CreateParkingLot(2)
+	secondLot := owner.CreateParkingLot(2)
+	smartAttendent := Implementations.AttendentConstruct(&Implementations.SmartNextLotStrategy{})
+	owner.AssignParkingLotToAttendent(smartAttendent, firstLot)
+	owner.AssignParkingLotToAttendent(smartAttendent, secondLot)
+
+	smartAttendent.Park(&Implementations.Car{RegistrationNumber: "AP-1234", Color: Enums.RED})
+	ticket, _ := smartAttendent.Park(&Implementations.Car{RegistrationNumber: "AP-5678", Color: Enums.BLUE})
+
+	decision, err := smartAttendent.Explain(ticket.GetTicketId())
+	assert.NoError(t, err)
+	assert.Equal(t, "SmartNextLotStrategy", decision.Strategy)
+	assert.Equal(t, secondLot.GetParkingLotId(), decision.SelectedParkingLotId)
+}
+
+func TestExplanationWhenAllLotsAreFull(t *testing.T) {
+	owner := Implementations.OwnerConstruct()
+	parkingLot := owner.CreateParkingLot(1)
+	attendent := Implementations.AttendentConstructDefault()
+	owner.AssignParkingLotToAttendent(attendent, parkingLot)
+
+	attendent.Park(&Implementations.Car{RegistrationNumber: "AP-1234", Color: Enums.RED})
+	ticket, decision, err := attendent.ParkWithExplanation(&Implementations.Car{RegistrationNumber: "AP-5678", Color: Enums.BLUE})
+
+	assert.Error(t, err)
+	assert.Nil(t, ticket)
+	assert.Len(t, decision.Candidates, 1)
+	assert.Equal(t, Implementations.CandidateFull, decision.Candidates[0].Outcome)
+}
+
+func TestExplainUnknownTicket(t *testing.T) {
+	attendent := Implementations.AttendentConstructDefault()
+
+	_, err := attendent.Explain("unknown")
+	assert.ErrorIs(t, err, Exceptions.ErrDecisionNotFound)
+}
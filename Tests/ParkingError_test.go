@@ -0,0 +1,106 @@
+package Tests
+
+import (
+	"ParkingLot_go/Enums"
+	"ParkingLot_go/Exceptions"
+	"ParkingLot_go/Implementations"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAttendentErrorsMatchSentinels(t *testing.T) {
+	owner := Implementations.OwnerConstruct()
+	parkingLot := owner.CreateParkingLot(2)
+	attendent := Implementations.AttendentConstructDefault()
+	owner.AssignParkingLotToAttendent(attendent, parkingLot)
+	car := &Implementations.Car{RegistrationNumber: "AP-1234", Color: Enums.RED}
+
+	attendent.Park(car)
+	_, err := attendent.Park(car)
+
+	assert.ErrorIs(t, err, Exceptions.ErrCarAlreadyAssigned)
+	assert.NotErrorIs(t, err, Exceptions.ErrCarAlreadyParked)
+	assert.Equal(t, Exceptions.CodeCarAlreadyAssigned, Exceptions.CodeOf(err))
+}
+
+func TestSentinelsSharingAStatusStayDistinct(t *testing.T) {
+	assert.NotErrorIs(t, Exceptions.ErrCarAlreadyAssigned, Exceptions.ErrCarAlreadyParked)
+	assert.NotErrorIs(t, Exceptions.ErrCarAlreadyParked, Exceptions.ErrCarAlreadyAssigned)
+	assert.Equal(t, Exceptions.ErrCarAlreadyParked.HTTPStatus(), Exceptions.ErrCarAlreadyAssigned.HTTPStatus())
+}
+
+func TestOwnershipErrorCarriesLotContext(t *testing.T) {
+	owner := Implementations.OwnerConstruct()
+	otherOwner := Implementations.OwnerConstruct()
+	parkingLot := otherOwner.CreateParkingLot(2)
+
+	err := owner.AssignParkingLotToAttendent(Implementations.AttendentConstructDefault(), parkingLot)
+
+	var parkingError *Exceptions.ParkingError
+	assert.True(t, errors.As(err, &parkingError))
+	assert.ErrorIs(t, err, Exceptions.ErrParkingLotNotOwned)
+	assert.Equal(t, parkingLot.GetParkingLotId(), parkingError.ParkingLotId)
+	assert.Equal(t, http.StatusForbidden, parkingError.HTTPStatus())
+	assert.Equal(t, Exceptions.GRPCPermissionDenied, parkingError.GRPCStatus())
+}
+
+func TestUnparkErrorCarriesTicketContext(t *testing.T) {
+	owner := Implementations.OwnerConstruct()
+	parkingLot := owner.CreateParkingLot(2)
+	attendent := Implementations.AttendentConstructDefault()
+	owner.AssignParkingLotToAttendent(attendent, parkingLot)
+	ticket, _ := attendent.Park(&Implementations.Car{RegistrationNumber: "AP-1234", Color: Enums.RED})
+	attendent.Unpark(ticket)
+
+	_, err := attendent.Unpark(ticket)
+
+	var parkingError *Exceptions.ParkingError
+	assert.True(t, errors.As(err, &parkingError))
+	assert.Equal(t, ticket.GetTicketId(), parkingError.TicketId)
+	assert.Equal(t, http.StatusNotFound, Exceptions.HTTPStatusOf(err))
+	assert.Equal(t, Exceptions.GRPCNotFound, Exceptions.GRPCStatusOf(err))
+}
+
+func TestParkingLotFullErrorCarriesLotAndRegistration(t *testing.T) {
+	owner := Implementations.OwnerConstruct()
+	parkingLot := owner.CreateParkingLot(1)
+	parkingLot.Park(&Implementations.Car{RegistrationNumber: "AP-1234", Color: Enums.RED})
+
+	_, err := parkingLot.Park(&Implementations.Car{RegistrationNumber: "AP-5678", Color: Enums.BLUE})
+
+	var parkingError *Exceptions.ParkingError
+	assert.True(t, errors.As(err, &parkingError))
+	assert.Equal(t, parkingLot.GetParkingLotId(), parkingError.ParkingLotId)
+	assert.Equal(t, "AP-5678", parkingError.RegistrationNumber)
+	assert.Contains(t, parkingError.Detail(), "registration=AP-5678")
+}
+
+func TestErrorMessageIncludesContext(t *testing.T) {
+	err := Exceptions.ErrParkingLotIsFull.WithLot(42).WithRegistration("AP-5678")
+
+	assert.Equal(t, "parking lot is full (lot=42, registration=AP-5678)", err.Error())
+	assert.Equal(t, "[PARKING_LOT_FULL] parking lot is full (lot=42, registration=AP-5678)", err.Detail())
+	assert.Equal(t, "parking lot is full", Exceptions.ErrParkingLotIsFull.Error())
+}
+
+func TestWrappedErrorKeepsCauseAndContext(t *testing.T) {
+	cause := Exceptions.ErrSlotIsOccupied.WithTicket("ticket-1")
+	err := Exceptions.ErrCarNotFound.WithLot(7).Wrap(cause)
+
+	assert.ErrorIs(t, err, Exceptions.ErrCarNotFound)
+	assert.ErrorIs(t, err, Exceptions.ErrSlotIsOccupied)
+	assert.Equal(t, 7, err.ParkingLotId)
+	assert.Equal(t, "ticket-1", err.TicketId)
+	assert.Equal(t, "car not found (lot=7, ticket=ticket-1, cause=slot is already occupied (ticket=ticket-1))", err.Error())
+}
+
+func TestUnknownErrorsMapToInternal(t *testing.T) {
+	err := errors.New("boom")
+
+	assert.Equal(t, Exceptions.CodeUnknown, Exceptions.CodeOf(err))
+	assert.Equal(t, http.StatusInternalServerError, Exceptions.HTTPStatusOf(err))
+	assert.Equal(t, Exceptions.GRPCInternal, Exceptions.GRPCStatusOf(err))
+}
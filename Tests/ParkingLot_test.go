@@ -25,6 +25,20 @@ func TestExceptionForNegativeParkingSlots(t *testing.T) {
 	})
 }
 
+func TestCheckedConstructionReportsInvalidLots(t *testing.T) {
+	owner := &Implementations.Owner{}
+
+	_, err := Implementations.ParkingLotConstructChecked(0, owner)
+	assert.Equal(t, Exceptions.ErrCannotCreateParkingLotException, err)
+
+	_, err = Implementations.ParkingLotConstructChecked(3, nil)
+	assert.Equal(t, Exceptions.ErrParkingLotNeedsOwner, err)
+
+	parkingLot, err := Implementations.ParkingLotConstructChecked(3, owner)
+	assert.NoError(t, err)
+	assert.Equal(t, owner, parkingLot.Owner)
+}
+
 func TestCreateParkingLotWith5Slots(t *testing.T) {
 	owner := &Implementations.Owner{}
 	parkingLot := Implementations.ParkingLotConstruct(5, owner)
@@ -52,7 +66,7 @@ func TestCannotParkSameCarTwice(t *testing.T) {
 	assert.NoError(t, err)
 
 	_, err = parkingLot.Park(car)
-	assert.EqualError(t, err, Exceptions.ErrCarAlreadyParked.Error())
+	assert.EqualError(t, err, Exceptions.ErrCarAlreadyParked.WithLot(parkingLot.GetParkingLotId()).WithRegistration("AP-1234").Error())
 }
 
 func TestParkingLotWithOneSlotIsFullWhenCarParked(t *testing.T) {
@@ -88,7 +102,7 @@ func TestParkInFullParkingLot(t *testing.T) {
 	assert.NoError(t, err)
 
 	_, err = parkingLot.Park(secondCar)
-	assert.EqualError(t, err, Exceptions.ErrParkingLotIsFull.Error())
+	assert.EqualError(t, err, Exceptions.ErrParkingLotIsFull.WithLot(parkingLot.GetParkingLotId()).WithRegistration("AP-5678").Error())
 }
 
 func TestParkInNearestAvailableSlot(t *testing.T) {
@@ -166,7 +180,7 @@ func TestUnparkCarThatIsNotParked(t *testing.T) {
 	_, err := parkingLot.Unpark(invalidTicket)
 
 	assert.NotNil(t, err)
-	assert.Equal(t, Exceptions.ErrInvalidTicket, err)
+	assert.ErrorIs(t, err, Exceptions.ErrInvalidTicket)
 }
 
 func TestUnparkCarFromEmptyParkingLot(t *testing.T) {
@@ -177,7 +191,7 @@ func TestUnparkCarFromEmptyParkingLot(t *testing.T) {
 	_, err := parkingLot.Unpark(invalidTicket)
 
 	assert.NotNil(t, err)
-	assert.Equal(t, Exceptions.ErrInvalidTicket, err)
+	assert.ErrorIs(t, err, Exceptions.ErrInvalidTicket)
 }
 
 func TestCountCarsByColor(t *testing.T) {
@@ -231,5 +245,5 @@ func TestIsCarWithoutRegistrationNumberCannotParked(t *testing.T) {
 	_, err := parkingLot.IsCarWithRegistrationNumberParked(car.RegistrationNumber)
 
 	assert.NotNil(t, err)
-	assert.Equal(t, Exceptions.ErrCarNeedsRegistrationNumber, err)
+	assert.ErrorIs(t, err, Exceptions.ErrCarNeedsRegistrationNumber)
 }
@@ -19,7 +19,7 @@ func TestPolicemanNotifiedWhenParkingLotIsFull(t *testing.T) {
 	assert.NoError(t, err)
 
 	_, err = parkingLot.Park(&Implementations.Car{LicensePlate: "AP-5678", Color: Enums.BLUE})
-	assert.EqualError(t, err, Exceptions.ErrParkingLotIsFull.Error())
+	assert.EqualError(t, err, Exceptions.ErrParkingLotIsFull.WithLot(parkingLot.GetParkingLotId()).Error())
 }
 
 func TestRegisterNotifiableToPolicemen(t *testing.T) {
@@ -52,9 +52,9 @@ func TestPolicemanNotifyFullAllParkingLotsAreFull(t *testing.T) {
 	assert.NoError(t, err)
 
 	_, err = firstLot.Park(&Implementations.Car{LicensePlate: "AP-9999", Color: Enums.GREEN})
-	assert.EqualError(t, err, Exceptions.ErrParkingLotIsFull.Error())
+	assert.EqualError(t, err, Exceptions.ErrParkingLotIsFull.WithLot(firstLot.GetParkingLotId()).Error())
 	_, err = secondLot.Park(&Implementations.Car{LicensePlate: "AP-1432", Color: Enums.YELLOW})
-	assert.EqualError(t, err, Exceptions.ErrParkingLotIsFull.Error())
+	assert.EqualError(t, err, Exceptions.ErrParkingLotIsFull.WithLot(secondLot.GetParkingLotId()).Error())
 }
 
 func TestPolicemanNotifyFullSomeParkingLotsAreFull(t *testing.T) {
@@ -72,7 +72,7 @@ func TestPolicemanNotifyFullSomeParkingLotsAreFull(t *testing.T) {
 	assert.NoError(t, err)
 
 	_, err = firstLot.Park(&Implementations.Car{LicensePlate: "AP-9999", Color: Enums.GREEN})
-	assert.EqualError(t, err, Exceptions.ErrParkingLotIsFull.Error())
+	assert.EqualError(t, err, Exceptions.ErrParkingLotIsFull.WithLot(firstLot.GetParkingLotId()).Error())
 }
 
 func TestPolicemanNotifiedWhenParkingLotAvailable(t *testing.T) {
@@ -129,7 +129,7 @@ func TestPolicemanNotifyFullSomeParkingLotsAreAvailable(t *testing.T) {
 	assert.NoError(t, err)
 
 	_, err = firstLot.Park(&Implementations.Car{LicensePlate: "AP-9999", Color: Enums.GREEN})
-	assert.EqualError(t, err, Exceptions.ErrParkingLotIsFull.Error())
+	assert.EqualError(t, err, Exceptions.ErrParkingLotIsFull.WithLot(firstLot.GetParkingLotId()).Error())
 
 	_, err = firstLot.Unpark(firstTicket)
 	assert.NoError(t, err)
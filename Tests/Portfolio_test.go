@@ -0,0 +1,81 @@
+package Tests
+
+import (
+	"ParkingLot_go/Enums"
+	"ParkingLot_go/Exceptions"
+	"ParkingLot_go/Implementations"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPortfolioAggregatesCapacityAndOccupancy(t *testing.T) {
+	owner := Implementations.OwnerConstruct()
+	owner.Clock = newManualClock()
+	smallLot := owner.CreateParkingLot(1)
+	largeLot := owner.CreateParkingLot(4)
+	smallLot.Park(&Implementations.Car{RegistrationNumber: "AP-1", Color: Enums.RED})
+	largeLot.Park(&Implementations.Car{RegistrationNumber: "AP-2", Color: Enums.RED, VehicleType: Enums.SUV})
+
+	summary, err := owner.Portfolio(5)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 5, summary.TotalCapacity)
+	assert.Equal(t, 2, summary.Occupied)
+	assert.Equal(t, 3, summary.Free)
+	assert.Equal(t, 40.0, summary.PercentFull)
+	assert.Equal(t, []int{smallLot.GetParkingLotId()}, summary.FullLots)
+	assert.Equal(t, 100.0, summary.Lots[0].PercentFull)
+	assert.Equal(t, 25.0, summary.Lots[1].PercentFull)
+	assert.Equal(t, 2, summary.ByColor[Enums.RED])
+	assert.Equal(t, 1, summary.ByVehicleType[Enums.CAR])
+	assert.Equal(t, 1, summary.ByVehicleType[Enums.SUV])
+}
+
+func TestPortfolioListsLongestParkedFirst(t *testing.T) {
+	clock := newManualClock()
+	owner := Implementations.OwnerConstruct()
+	owner.Clock = clock
+	parkingLot := owner.CreateParkingLot(3)
+	parkingLot.Park(&Implementations.Car{RegistrationNumber: "AP-1", Color: Enums.RED})
+	clock.Advance(time.Hour)
+	parkingLot.Park(&Implementations.Car{RegistrationNumber: "AP-2", Color: Enums.BLUE})
+	clock.Advance(time.Hour)
+	parkingLot.Park(&Implementations.Car{RegistrationNumber: "AP-3", Color: Enums.GREEN})
+
+	summary, _ := owner.Portfolio(2)
+
+	assert.Len(t, summary.LongestParked, 2)
+	assert.Equal(t, "AP-1", summary.LongestParked[0].Car.RegistrationNumber)
+	assert.Equal(t, 2*time.Hour, summary.LongestParked[0].Duration)
+	assert.Equal(t, 1, summary.LongestParked[0].Slot.SlotNumber)
+	assert.Equal(t, "AP-2", summary.LongestParked[1].Car.RegistrationNumber)
+}
+
+func TestOccupancySeriesKeepsARollingWindow(t *testing.T) {
+	clock := newManualClock()
+	owner := Implementations.OwnerConstruct()
+	owner.Clock = clock
+	parkingLot := owner.CreateParkingLot(2)
+	ticket, _ := parkingLot.Park(&Implementations.Car{RegistrationNumber: "AP-1", Color: Enums.RED})
+	clock.Advance(Implementations.OccupancyRetention + time.Minute)
+	parkingLot.Park(&Implementations.Car{RegistrationNumber: "AP-2", Color: Enums.RED})
+	parkingLot.Unpark(ticket)
+
+	series := parkingLot.OccupancySeries()
+
+	assert.Len(t, series, 2)
+	assert.Equal(t, 100.0, series[0].PercentFull)
+	assert.Equal(t, 1, series[1].Occupied)
+	assert.Equal(t, clock.Now(), series[1].At)
+}
+
+func TestPortfolioRequiresViewReportsPermission(t *testing.T) {
+	owner := Implementations.OwnerConstruct()
+	owner.EnableAccessControl(Implementations.AccessControlConstruct(nil))
+	owner.Principal = Implementations.PrincipalConstruct("clerk", Implementations.RoleAttendent)
+
+	_, err := owner.Portfolio(1)
+	assert.ErrorIs(t, err, Exceptions.ErrPermissionDenied)
+}
@@ -0,0 +1,187 @@
+package Tests
+
+import (
+	"ParkingLot_go/Enums"
+	"ParkingLot_go/Exceptions"
+	"ParkingLot_go/Implementations"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRevokeLotMovesCarsToOwner(t *testing.T) {
+	owner := Implementations.OwnerConstruct()
+	parkingLot := owner.CreateParkingLot(2)
+	attendent := Implementations.AttendentConstructDefault()
+	owner.AssignParkingLotToAttendent(attendent, parkingLot)
+	ticket, _ := attendent.Park(&Implementations.Car{RegistrationNumber: "AP-1234", Color: Enums.RED})
+
+	revocation, err := owner.RevokeParkingLotFromAttendent(attendent, parkingLot)
+
+	assert.NoError(t, err)
+	assert.Equal(t, Implementations.LotRevoked, revocation.Kind)
+	assert.Nil(t, revocation.TransferredTo)
+	assert.Equal(t, []string{ticket.GetTicketId()}, revocation.TicketIds)
+	assert.Empty(t, attendent.AssignedParkingLots)
+	assert.Empty(t, attendent.ParkedCars)
+	car, err := owner.DispatchUnpark(ticket)
+	assert.NoError(t, err)
+	assert.Equal(t, "AP-1234", car.RegistrationNumber)
+}
+
+func TestRevokeLotMovesCarsToAnotherAttendentOfTheLot(t *testing.T) {
+	owner := Implementations.OwnerConstruct()
+	parkingLot := owner.CreateParkingLot(2)
+	firstAttendent := Implementations.AttendentConstructDefault()
+	secondAttendent := Implementations.AttendentConstructDefault()
+	owner.AssignParkingLotToAttendent(firstAttendent, parkingLot)
+	owner.AssignParkingLotToAttendent(secondAttendent, parkingLot)
+	ticket, _ := firstAttendent.Park(&Implementations.Car{RegistrationNumber: "AP-1234", Color: Enums.RED})
+
+	revocation, err := owner.RevokeParkingLotFromAttendent(firstAttendent, parkingLot)
+
+	assert.NoError(t, err)
+	assert.Equal(t, secondAttendent, revocation.TransferredTo)
+	assert.True(t, secondAttendent.HoldsTicket(ticket))
+	_, err = secondAttendent.Explain(ticket.GetTicketId())
+	assert.NoError(t, err)
+	assert.NoError(t, secondAttendent.Validate())
+}
+
+func TestRevokeLotNotAssignedToAttendent(t *testing.T) {
+	owner := Implementations.OwnerConstruct()
+	parkingLot := owner.CreateParkingLot(2)
+	attendent := Implementations.AttendentConstructDefault()
+
+	_, err := owner.RevokeParkingLotFromAttendent(attendent, parkingLot)
+	assert.ErrorIs(t, err, Exceptions.ErrParkingLotNotAssigned)
+}
+
+func TestFireAttendentTransfersCarsToSuccessor(t *testing.T) {
+	owner := Implementations.OwnerConstruct()
+	firstLot := owner.CreateParkingLot(1)
+	secondLot := owner.CreateParkingLot(1)
+	leaving := Implementations.AttendentConstructDefault()
+	staying := Implementations.AttendentConstructDefault()
+	owner.AssignParkingLotToAttendent(leaving, firstLot)
+	owner.AssignParkingLotToAttendent(leaving, secondLot)
+	owner.HireAttendent(staying)
+	firstTicket, _ := leaving.Park(&Implementations.Car{RegistrationNumber: "AP-1234", Color: Enums.RED})
+	secondTicket, _ := leaving.Park(&Implementations.Car{RegistrationNumber: "AP-5678", Color: Enums.BLUE})
+
+	revocation, err := owner.FireAttendent(leaving, staying)
+
+	assert.NoError(t, err)
+	assert.Equal(t, Implementations.AttendentFired, revocation.Kind)
+	assert.Len(t, revocation.ParkingLotIds, 2)
+	assert.Equal(t, []*Implementations.Attendent{staying}, owner.Attendents)
+	assert.Nil(t, leaving.AssignedOwner)
+	assert.True(t, staying.HoldsTicket(firstTicket))
+	assert.True(t, staying.HoldsTicket(secondTicket))
+	assert.Len(t, staying.AssignedParkingLots, 2)
+}
+
+func TestFireAttendentWithoutSuccessorHandsCarsToOwner(t *testing.T) {
+	owner := Implementations.OwnerConstruct()
+	parkingLot := owner.CreateParkingLot(1)
+	attendent := Implementations.AttendentConstructDefault()
+	owner.AssignParkingLotToAttendent(attendent, parkingLot)
+	ticket, _ := attendent.Park(&Implementations.Car{RegistrationNumber: "AP-1234", Color: Enums.RED})
+
+	_, err := owner.FireAttendent(attendent, nil)
+	assert.NoError(t, err)
+
+	car, err := owner.Unpark(ticket)
+	assert.NoError(t, err)
+	assert.Equal(t, "AP-1234", car.RegistrationNumber)
+}
+
+func TestFireAttendentNotEmployed(t *testing.T) {
+	owner := Implementations.OwnerConstruct()
+
+	_, err := owner.FireAttendent(Implementations.AttendentConstructDefault(), nil)
+	assert.ErrorIs(t, err, Exceptions.ErrAttendentNotEmployed)
+}
+
+func TestRevocationsAreRecordedAndPublished(t *testing.T) {
+	owner := Implementations.OwnerConstruct()
+	firstLot := owner.CreateParkingLot(1)
+	secondLot := owner.CreateParkingLot(1)
+	attendent := Implementations.AttendentConstructDefault()
+	owner.AssignParkingLotToAttendent(attendent, firstLot)
+	owner.AssignParkingLotToAttendent(attendent, secondLot)
+	ticket, _ := attendent.Park(&Implementations.Car{RegistrationNumber: "AP-1234", Color: Enums.RED})
+	recorder := &eventRecorder{}
+	owner.SubscribeAll(recorder, Implementations.EventFilter{EventTypes: []Implementations.EventType{Implementations.AttendentRevoked}})
+
+	owner.RevokeParkingLotFromAttendent(attendent, secondLot)
+	owner.FireAttendent(attendent, nil)
+
+	assert.Len(t, owner.Revocations, 2)
+	assert.Len(t, recorder.events, 2)
+	assert.Equal(t, secondLot.ParkingLotId, recorder.events[0].ParkingLotId)
+	assert.Equal(t, string(Implementations.LotRevoked), recorder.events[0].Reason)
+	assert.Empty(t, recorder.events[0].TicketIds)
+	assert.Equal(t, firstLot.ParkingLotId, recorder.events[1].ParkingLotId)
+	assert.Equal(t, string(Implementations.AttendentFired), recorder.events[1].Reason)
+	assert.Equal(t, attendent.Id, recorder.events[1].AttendentId)
+	assert.Equal(t, owner.Attendent.Id, recorder.events[1].SuccessorId)
+	assert.Equal(t, []string{ticket.GetTicketId()}, recorder.events[1].TicketIds)
+}
+
+func TestFireAttendentMovesNothingWhenALotCannotMove(t *testing.T) {
+	owner := Implementations.OwnerConstruct()
+	firstLot := owner.CreateParkingLot(1)
+	secondLot := owner.CreateParkingLot(1)
+	attendent := Implementations.AttendentConstructDefault()
+	successor := Implementations.AttendentConstructDefault()
+	owner.AssignParkingLotToAttendent(attendent, firstLot)
+	owner.AssignParkingLotToAttendent(attendent, secondLot)
+	owner.AssignParkingLotToAttendent(successor, firstLot)
+	first, _ := attendent.Park(&Implementations.Car{RegistrationNumber: "AP-1", Color: Enums.RED})
+	second, _ := attendent.Park(&Implementations.Car{RegistrationNumber: "AP-2", Color: Enums.RED})
+	successor.AssignedOwner = Implementations.OwnerConstruct()
+	recorder := &eventRecorder{}
+	owner.SubscribeAll(recorder, Implementations.EventFilter{EventTypes: []Implementations.EventType{Implementations.AttendentRevoked}})
+
+	_, err := owner.FireAttendent(attendent, successor)
+
+	assert.ErrorIs(t, err, Exceptions.ErrParkingLotNotOwned)
+	assert.Equal(t, []*Implementations.ParkingLot{firstLot, secondLot}, attendent.AssignedLots())
+	assert.True(t, attendent.HoldsTicket(first))
+	assert.True(t, attendent.HoldsTicket(second))
+	assert.False(t, successor.HoldsTicket(first))
+	assert.Equal(t, []*Implementations.ParkingLot{firstLot}, successor.AssignedLots())
+	assert.Empty(t, owner.Revocations)
+	assert.Empty(t, recorder.events)
+	assert.NoError(t, attendent.Validate())
+	assert.NoError(t, successor.Validate())
+}
+
+func TestRevocationsAreStampedWithTheOwnersClock(t *testing.T) {
+	clock := newManualClock()
+	owner := Implementations.OwnerConstruct()
+	owner.Clock = clock
+	buyer := Implementations.OwnerConstruct()
+	firstLot := owner.CreateParkingLot(1)
+	secondLot := owner.CreateParkingLot(1)
+	soldLot := owner.CreateParkingLot(1)
+	attendent := Implementations.AttendentConstructDefault()
+	seller := Implementations.AttendentConstructDefault()
+	owner.AssignParkingLotToAttendent(attendent, firstLot)
+	owner.AssignParkingLotToAttendent(attendent, secondLot)
+	owner.AssignParkingLotToAttendent(seller, soldLot)
+
+	revoked, err := owner.RevokeParkingLotFromAttendent(attendent, secondLot)
+	assert.NoError(t, err)
+	fired, err := owner.FireAttendent(attendent, nil)
+	assert.NoError(t, err)
+	transfer, err := owner.TransferParkingLot(soldLot, buyer, Implementations.TransferTerms{Policy: Implementations.RevokeAttendents})
+	assert.NoError(t, err)
+
+	assert.Equal(t, clock.Now(), revoked.At)
+	assert.Equal(t, clock.Now(), fired.At)
+	assert.Equal(t, clock.Now(), transfer.At)
+	assert.Len(t, owner.Revocations, 3)
+	assert.Equal(t, clock.Now(), owner.Revocations[2].At)
+}
@@ -0,0 +1,234 @@
+package Tests
+
+import (
+	"ParkingLot_go/Enums"
+	"ParkingLot_go/Exceptions"
+	"ParkingLot_go/Implementations"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAttendentWithoutRosterIsAlwaysOnDuty(t *testing.T) {
+	owner := Implementations.OwnerConstruct()
+	parkingLot := owner.CreateParkingLot(1)
+	attendent := Implementations.AttendentConstructDefault()
+	owner.AssignParkingLotToAttendent(attendent, parkingLot)
+
+	_, err := attendent.Park(&Implementations.Car{RegistrationNumber: "AP-1234", Color: Enums.RED})
+	assert.NoError(t, err)
+}
+
+func TestOffShiftAttendentCannotPark(t *testing.T) {
+	clock := newManualClock()
+	roster := Implementations.RosterConstruct(clock)
+	owner := Implementations.OwnerConstruct()
+	parkingLot := owner.CreateParkingLot(1)
+	attendent := Implementations.AttendentConstructDefault()
+	owner.AssignParkingLotToAttendent(attendent, parkingLot)
+	roster.Schedule(attendent, clock.Now().Add(time.Hour), clock.Now().Add(9*time.Hour))
+
+	_, err := attendent.Park(&Implementations.Car{RegistrationNumber: "AP-1234", Color: Enums.RED})
+	assert.ErrorIs(t, err, Exceptions.ErrAttendentOffShift)
+}
+
+func TestClockInOnlyDuringScheduledShift(t *testing.T) {
+	clock := newManualClock()
+	roster := Implementations.RosterConstruct(clock)
+	attendent := Implementations.AttendentConstructDefault()
+	roster.Schedule(attendent, clock.Now().Add(time.Hour), clock.Now().Add(9*time.Hour))
+
+	_, err := roster.ClockIn(attendent)
+	assert.ErrorIs(t, err, Exceptions.ErrNoScheduledShift)
+
+	clock.Advance(time.Hour + 5*time.Minute)
+	shift, err := roster.ClockIn(attendent)
+	assert.NoError(t, err)
+	assert.True(t, roster.IsOnDuty(attendent))
+	assert.Equal(t, 5*time.Minute, shift.Report(clock.Now()).LateBy)
+
+	_, err = roster.ClockIn(attendent)
+	assert.ErrorIs(t, err, Exceptions.ErrAlreadyClockedIn)
+}
+
+func TestScheduleRejectsInvalidAndOverlappingShifts(t *testing.T) {
+	clock := newManualClock()
+	roster := Implementations.RosterConstruct(clock)
+	attendent := Implementations.AttendentConstructDefault()
+
+	_, err := roster.Schedule(attendent, clock.Now(), clock.Now())
+	assert.ErrorIs(t, err, Exceptions.ErrInvalidShift)
+
+	_, err = roster.Schedule(attendent, clock.Now(), clock.Now().Add(8*time.Hour))
+	assert.NoError(t, err)
+	_, err = roster.Schedule(attendent, clock.Now().Add(4*time.Hour), clock.Now().Add(12*time.Hour))
+	assert.ErrorIs(t, err, Exceptions.ErrShiftOverlaps)
+}
+
+func TestClockOutRequiresHandoverOfParkedCars(t *testing.T) {
+	clock := newManualClock()
+	roster := Implementations.RosterConstruct(clock)
+	owner := Implementations.OwnerConstruct()
+	parkingLot := owner.CreateParkingLot(2)
+	morning := Implementations.AttendentConstructDefault()
+	evening := Implementations.AttendentConstructDefault()
+	owner.AssignParkingLotToAttendent(morning, parkingLot)
+	roster.Schedule(morning, clock.Now(), clock.Now().Add(8*time.Hour))
+	roster.Schedule(evening, clock.Now().Add(7*time.Hour), clock.Now().Add(15*time.Hour))
+
+	roster.ClockIn(morning)
+	ticket, err := morning.Park(&Implementations.Car{RegistrationNumber: "AP-1234", Color: Enums.RED})
+	assert.NoError(t, err)
+
+	clock.Advance(7 * time.Hour)
+	_, err = roster.ClockOut(morning)
+	assert.ErrorIs(t, err, Exceptions.ErrPendingHandover)
+
+	roster.ClockIn(evening)
+	handover, err := roster.Handover(morning, evening)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{ticket.GetTicketId()}, handover.TicketIds)
+	assert.Empty(t, morning.ParkedCars)
+	assert.Len(t, evening.ParkedCars, 1)
+
+	_, err = roster.ClockOut(morning)
+	assert.NoError(t, err)
+	_, err = morning.Park(&Implementations.Car{RegistrationNumber: "AP-5678", Color: Enums.BLUE})
+	assert.ErrorIs(t, err, Exceptions.ErrAttendentOffShift)
+
+	car, err := evening.Unpark(ticket)
+	assert.NoError(t, err)
+	assert.Equal(t, "AP-1234", car.RegistrationNumber)
+	assert.NoError(t, evening.Validate())
+}
+
+func TestHandoverRequiresIncomingAttendentOnDuty(t *testing.T) {
+	clock := newManualClock()
+	roster := Implementations.RosterConstruct(clock)
+	outgoing := Implementations.AttendentConstructDefault()
+	incoming := Implementations.AttendentConstructDefault()
+	roster.Schedule(outgoing, clock.Now(), clock.Now().Add(8*time.Hour))
+	roster.ClockIn(outgoing)
+
+	_, err := roster.Handover(outgoing, incoming)
+	assert.ErrorIs(t, err, Exceptions.ErrNotClockedIn)
+}
+
+func TestShiftReportSummarisesActivity(t *testing.T) {
+	clock := newManualClock()
+	roster := Implementations.RosterConstruct(clock)
+	owner := Implementations.OwnerConstruct()
+	parkingLot := owner.CreateParkingLot(3)
+	attendent := Implementations.AttendentConstructDefault()
+	owner.AssignParkingLotToAttendent(attendent, parkingLot)
+	roster.Schedule(attendent, clock.Now(), clock.Now().Add(8*time.Hour))
+	roster.ClockIn(attendent)
+
+	ticket, _ := attendent.Park(&Implementations.Car{RegistrationNumber: "AP-1234", Color: Enums.RED})
+	attendent.Park(&Implementations.Car{RegistrationNumber: "AP-5678", Color: Enums.BLUE})
+	attendent.Unpark(ticket)
+	clock.Advance(3 * time.Hour)
+
+	reports := roster.ShiftReports(attendent)
+	assert.Len(t, reports, 1)
+	assert.Equal(t, 2, reports[0].CarsParked)
+	assert.Equal(t, 1, reports[0].CarsUnparked)
+	assert.Equal(t, 3*time.Hour, reports[0].Worked)
+}
+
+func TestShiftEndsAtItsEndEvenWithoutClockingOut(t *testing.T) {
+	clock := newManualClock()
+	roster := Implementations.RosterConstruct(clock)
+	owner := Implementations.OwnerConstruct()
+	parkingLot := owner.CreateParkingLot(2)
+	attendent := Implementations.AttendentConstructDefault()
+	owner.AssignParkingLotToAttendent(attendent, parkingLot)
+	shift, _ := roster.Schedule(attendent, clock.Now(), clock.Now().Add(8*time.Hour))
+	roster.ClockIn(attendent)
+
+	clock.Advance(9 * time.Hour)
+	_, err := attendent.Park(&Implementations.Car{RegistrationNumber: "AP-1234", Color: Enums.RED})
+
+	assert.ErrorIs(t, err, Exceptions.ErrAttendentOffShift)
+	assert.False(t, shift.IsActive(clock.Now()))
+	assert.False(t, roster.IsOnDuty(attendent))
+	assert.Equal(t, 8*time.Hour, shift.Report(clock.Now()).Worked)
+	clockedOut, err := roster.ClockOut(attendent)
+	assert.NoError(t, err)
+	assert.Equal(t, shift, clockedOut)
+}
+
+func TestAttendentCanOnlyBeOnOneRoster(t *testing.T) {
+	clock := newManualClock()
+	first := Implementations.RosterConstruct(clock)
+	second := Implementations.RosterConstruct(clock)
+	attendent := Implementations.AttendentConstructDefault()
+	_, err := first.Schedule(attendent, clock.Now(), clock.Now().Add(8*time.Hour))
+	assert.NoError(t, err)
+
+	_, err = second.Schedule(attendent, clock.Now().Add(24*time.Hour), clock.Now().Add(32*time.Hour))
+
+	assert.ErrorIs(t, err, Exceptions.ErrAttendentOnAnotherRoster)
+	assert.Empty(t, second.ShiftsOf(attendent))
+	_, err = first.Schedule(attendent, clock.Now().Add(24*time.Hour), clock.Now().Add(32*time.Hour))
+	assert.NoError(t, err)
+}
+
+func TestHandoverAssignsLotsOnBehalfOfTheirOwner(t *testing.T) {
+	clock := newManualClock()
+	roster := Implementations.RosterConstruct(clock)
+	client := Implementations.OwnerConstruct()
+	clientLot := client.CreateParkingLot(2)
+	outgoing := Implementations.AttendentConstructDefault()
+	incoming := Implementations.AttendentConstructDefault()
+	outgoing.Clock = clock
+	incoming.Clock = clock
+	_, err := client.ContractAttendent(outgoing, []*Implementations.ParkingLot{clientLot}, clock.Now(), clock.Now().Add(8*time.Hour), 0)
+	assert.NoError(t, err)
+	roster.Schedule(outgoing, clock.Now(), clock.Now().Add(8*time.Hour))
+	roster.Schedule(incoming, clock.Now(), clock.Now().Add(8*time.Hour))
+	roster.ClockIn(outgoing)
+	roster.ClockIn(incoming)
+	ticket, err := outgoing.Park(&Implementations.Car{RegistrationNumber: "AP-1234", Color: Enums.RED})
+	assert.NoError(t, err)
+
+	_, err = roster.Handover(outgoing, incoming)
+
+	assert.NoError(t, err)
+	assert.Nil(t, outgoing.AssignedOwner)
+	assert.Equal(t, client, incoming.AssignedOwner)
+	_, err = incoming.Unpark(ticket)
+	assert.NoError(t, err)
+}
+
+func TestHandoverMovesNothingWhenALotCannotBeAssigned(t *testing.T) {
+	clock := newManualClock()
+	roster := Implementations.RosterConstruct(clock)
+	employer := Implementations.OwnerConstruct()
+	client := Implementations.OwnerConstruct()
+	employerLot := employer.CreateParkingLot(2)
+	clientLot := client.CreateParkingLot(2)
+	outgoing := Implementations.AttendentConstructDefault()
+	incoming := Implementations.AttendentConstructDefault()
+	outgoing.Clock = clock
+	incoming.Clock = clock
+	assert.NoError(t, employer.AssignParkingLotToAttendent(outgoing, employerLot))
+	assert.NoError(t, employer.AssignParkingLotToAttendent(incoming, employer.CreateParkingLot(1)))
+	_, err := client.ContractAttendent(outgoing, []*Implementations.ParkingLot{clientLot}, clock.Now(), clock.Now().Add(8*time.Hour), 0)
+	assert.NoError(t, err)
+	roster.Schedule(outgoing, clock.Now(), clock.Now().Add(8*time.Hour))
+	roster.Schedule(incoming, clock.Now(), clock.Now().Add(8*time.Hour))
+	roster.ClockIn(outgoing)
+	roster.ClockIn(incoming)
+	ticket, err := outgoing.Park(&Implementations.Car{RegistrationNumber: "AP-1234", Color: Enums.RED})
+	assert.NoError(t, err)
+
+	_, err = roster.Handover(outgoing, incoming)
+
+	assert.ErrorIs(t, err, Exceptions.ErrParkingLotNotOwned)
+	assert.Len(t, incoming.AssignedParkingLots, 1)
+	assert.NotContains(t, incoming.AssignedParkingLots, employerLot)
+	assert.True(t, outgoing.HoldsTicket(ticket))
+	assert.Empty(t, roster.Handovers)
+}
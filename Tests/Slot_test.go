@@ -2,6 +2,7 @@ package Tests
 
 import (
 	"ParkingLot_go/Enums"
+	"ParkingLot_go/Exceptions"
 	"ParkingLot_go/Implementations"
 	"testing"
 
@@ -34,7 +35,7 @@ func TestCannotParkCarInOccupiedSlot(t *testing.T) {
 	anotherCar := Implementations.NewCar("AP-5678", Enums.BLUE) // Update to Enums.BLUE
 	_, err = slot.Park(anotherCar)
 	assert.Error(t, err)
-	assert.Equal(t, "slot is already occupied", err.Error())
+	assert.Equal(t, Exceptions.ErrSlotIsOccupied.WithRegistration("AP-5678").Error(), err.Error())
 }
 
 func TestUnparkCarFromOccupiedSlot(t *testing.T) {
@@ -56,7 +57,7 @@ func TestCannotUnparkCarFromFreeSlot(t *testing.T) {
 
 	_, err := slot.Unpark(invalidTicket)
 	assert.Error(t, err)
-	assert.Equal(t, "car not found in the slot", err.Error())
+	assert.Equal(t, Exceptions.ErrSlotIsEmpty.WithTicket(invalidTicket.GetTicketId()).Error(), err.Error())
 }
 
 func TestHasCarOfSameColor(t *testing.T) {
@@ -0,0 +1,197 @@
+package Tests
+
+import (
+	"ParkingLot_go/Enums"
+	"ParkingLot_go/Exceptions"
+	"ParkingLot_go/Implementations"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSnapshotRoundTripKeepsTicketsWorking(t *testing.T) {
+	clock := newManualClock()
+	owner := Implementations.OwnerConstruct()
+	owner.Clock = clock
+	parkingLot := owner.CreateParkingLot(3)
+	parkingLot.Name = "Central Garage"
+	owner.SetSlotType(parkingLot, 3, Enums.LARGE)
+	owner.TakeSlotOutOfService(parkingLot, 2)
+	attendent := Implementations.AttendentConstruct(&Implementations.SmartNextLotStrategy{})
+	owner.AssignParkingLotToAttendent(attendent, parkingLot)
+	owner.AssignParkingLotToSelf(parkingLot)
+	attendentTicket, _ := attendent.Park(&Implementations.Car{RegistrationNumber: "AP-1", Color: Enums.RED})
+	ownerTicket, _ := owner.Park(&Implementations.Car{RegistrationNumber: "AP-2", Color: Enums.BLUE, VehicleType: Enums.SUV})
+
+	data, err := owner.Snapshot(nil)
+	assert.NoError(t, err)
+	restored, err := Implementations.RestoreOwner(data, nil)
+	assert.NoError(t, err)
+
+	restoredLot := restored.OwnerParkingLots[0]
+	restoredAttendent := restored.Attendents[0]
+	assert.Equal(t, owner.Id, restored.Id)
+	assert.Equal(t, attendent.Id, restoredAttendent.Id)
+	assert.Equal(t, parkingLot.ParkingLotId, restoredLot.ParkingLotId)
+	assert.Equal(t, "Central Garage", restoredLot.Name)
+	assert.Equal(t, 2, restoredLot.Capacity())
+	assert.True(t, restoredLot.IsFull())
+	assert.IsType(t, &Implementations.SmartNextLotStrategy{}, restoredAttendent.NextLotStrategy)
+	assert.Equal(t, restored, restoredAttendent.AssignedOwner)
+	assert.NoError(t, restoredAttendent.Validate())
+	assert.NoError(t, restored.Attendent.Validate())
+
+	car, err := restored.DispatchUnpark(attendentTicket)
+	assert.NoError(t, err)
+	assert.Equal(t, "AP-1", car.RegistrationNumber)
+	car, err = restored.DispatchUnpark(ownerTicket)
+	assert.NoError(t, err)
+	assert.Equal(t, Enums.SUV, car.VehicleType)
+}
+
+func TestSnapshotOfARestoredOwnerIsUnchanged(t *testing.T) {
+	clock := newManualClock()
+	owner := Implementations.OwnerConstruct()
+	owner.Clock = clock
+	parkingLot := owner.CreateParkingLot(2)
+	parkingLot.SetOccupancyThresholds(Implementations.OccupancyThreshold{Percent: 80, Hysteresis: 10})
+	attendent := Implementations.AttendentConstructDefault()
+	owner.AssignParkingLotToAttendent(attendent, parkingLot)
+	attendent.Park(&Implementations.Car{RegistrationNumber: "AP-1", Color: Enums.RED})
+	owner.DeclareEmergency(parkingLot, "fire drill")
+
+	data, _ := owner.Snapshot(nil)
+	restored, err := Implementations.RestoreOwner(data, nil)
+	assert.NoError(t, err)
+	restored.Clock = clock
+	again, _ := restored.Snapshot(nil)
+
+	assert.JSONEq(t, string(data), string(again))
+	assert.True(t, restored.OwnerParkingLots[0].InEmergency())
+	assert.Len(t, restored.EvacuationList(), 1)
+}
+
+func TestSnapshotRestoresNamedSubscriptions(t *testing.T) {
+	owner := Implementations.OwnerConstruct()
+	parkingLot := owner.CreateParkingLot(1)
+	lotRecorder := &eventRecorder{}
+	parkingLot.Subscribe(lotRecorder, Implementations.CarParked)
+	allRecorder := &eventRecorder{}
+	owner.SubscribeAll(allRecorder, Implementations.EventFilter{EventTypes: []Implementations.EventType{Implementations.LotFull}})
+	parkingLot.Subscribe(&eventRecorder{})
+
+	data, _ := owner.Snapshot(map[string]Implementations.Notifiable{"lot": lotRecorder, "all": allRecorder})
+	restoredLot := &eventRecorder{}
+	restoredAll := &eventRecorder{}
+	restored, err := Implementations.RestoreOwner(data, map[string]Implementations.Notifiable{"lot": restoredLot, "all": restoredAll})
+	assert.NoError(t, err)
+	restored.OwnerParkingLots[0].Park(&Implementations.Car{RegistrationNumber: "AP-1", Color: Enums.RED})
+	restored.CreateParkingLot(1).Park(&Implementations.Car{RegistrationNumber: "AP-2", Color: Enums.RED})
+
+	var snapshot Implementations.OwnerSnapshot
+	json.Unmarshal(data, &snapshot)
+	assert.Len(t, snapshot.Subscriptions, 3)
+	assert.Equal(t, []Implementations.EventType{Implementations.CarParked}, restoredLot.types())
+	assert.Equal(t, []Implementations.EventType{Implementations.LotFull, Implementations.LotFull}, restoredAll.types())
+}
+
+func TestSnapshotRestoresNamedThresholdSubscriptions(t *testing.T) {
+	owner := Implementations.OwnerConstruct()
+	parkingLot := owner.CreateParkingLot(2)
+	recorder := &eventRecorder{}
+	parkingLot.SubscribeThresholds(recorder, Implementations.OccupancyThreshold{Percent: 50})
+
+	data, _ := owner.Snapshot(map[string]Implementations.Notifiable{"recorder": recorder})
+	restoredRecorder := &eventRecorder{}
+	restored, err := Implementations.RestoreOwner(data, map[string]Implementations.Notifiable{"recorder": restoredRecorder})
+	assert.NoError(t, err)
+	restored.OwnerParkingLots[0].Park(&Implementations.Car{RegistrationNumber: "AP-1", Color: Enums.RED})
+
+	assert.Equal(t, []Implementations.EventType{Implementations.OccupancyAboveThreshold}, restoredRecorder.types())
+}
+
+func TestRestoreKeepsAMultiLotSubscriptionWhole(t *testing.T) {
+	owner := Implementations.OwnerConstruct()
+	firstLot := owner.CreateParkingLot(1)
+	secondLot := owner.CreateParkingLot(1)
+	recorder := &eventRecorder{}
+	firstLot.Subscribe(recorder, Implementations.CarParked)
+	data, _ := owner.Snapshot(map[string]Implementations.Notifiable{"recorder": recorder})
+	var snapshot Implementations.OwnerSnapshot
+	json.Unmarshal(data, &snapshot)
+	for i, subscription := range snapshot.Subscriptions {
+		if subscription.Subscriber == "recorder" {
+			snapshot.Subscriptions[i].ParkingLotIds = []int{firstLot.ParkingLotId, secondLot.ParkingLotId}
+		}
+	}
+	data, _ = json.Marshal(snapshot)
+
+	restoredRecorder := &eventRecorder{}
+	subscribers := map[string]Implementations.Notifiable{"recorder": restoredRecorder}
+	restored, err := Implementations.RestoreOwner(data, subscribers)
+	assert.NoError(t, err)
+	resnapshot, _ := restored.Snapshot(subscribers)
+	json.Unmarshal(resnapshot, &snapshot)
+
+	recorded := []Implementations.SubscriptionSnapshot{}
+	for _, subscription := range snapshot.Subscriptions {
+		if subscription.Subscriber == "recorder" {
+			recorded = append(recorded, subscription)
+		}
+	}
+	assert.Len(t, recorded, 1)
+	assert.Equal(t, []int{firstLot.ParkingLotId, secondLot.ParkingLotId}, recorded[0].ParkingLotIds)
+	restored.OwnerParkingLots[1].Park(&Implementations.Car{RegistrationNumber: "AP-1", Color: Enums.RED})
+	assert.Equal(t, []Implementations.EventType{Implementations.CarParked}, restoredRecorder.types())
+}
+
+func TestRestoreKeepsAccessControlAndTheStateLog(t *testing.T) {
+	owner := Implementations.OwnerConstruct()
+	accessControl := Implementations.AccessControlConstruct(nil)
+	accessControl.Grant(Implementations.RolePolice, Implementations.PermissionPark)
+	owner.EnableAccessControl(accessControl)
+	owner.EnableStateLog(Implementations.StateLogConstruct())
+	parkingLot := owner.CreateParkingLot(3)
+	attendent := Implementations.AttendentConstructDefault()
+	attendent.Principal = Implementations.PrincipalConstruct("ravi", Implementations.RoleAttendent)
+	assert.NoError(t, owner.AssignParkingLotToAttendent(attendent, parkingLot))
+
+	data, err := owner.Snapshot(nil)
+	assert.NoError(t, err)
+	restored, err := Implementations.RestoreOwner(data, nil)
+	assert.NoError(t, err)
+	restoredLot := restored.OwnerParkingLots[0]
+
+	_, err = restoredLot.Park(&Implementations.Car{RegistrationNumber: "AP-1", Color: Enums.RED})
+	assert.ErrorIs(t, err, Exceptions.ErrPermissionDenied)
+	assert.Equal(t, owner.Principal.Name, restored.Principal.Name)
+	_, err = restoredLot.ParkAs(restored.Principal, &Implementations.Car{RegistrationNumber: "AP-1", Color: Enums.RED})
+	assert.NoError(t, err)
+	_, err = restored.Attendents[0].Park(&Implementations.Car{RegistrationNumber: "AP-2", Color: Enums.RED})
+	assert.NoError(t, err)
+	_, err = restoredLot.ParkAs(Implementations.PrincipalConstruct("officer", Implementations.RolePolice), &Implementations.Car{RegistrationNumber: "AP-3", Color: Enums.RED})
+	assert.NoError(t, err)
+
+	assert.NotNil(t, restored.StateLog())
+	state, err := restored.StateLog().State()
+	assert.NoError(t, err)
+	assert.Equal(t, 3, state.ParkingLots[parkingLot.ParkingLotId].CountParkedCars())
+}
+
+func TestRestoreRejectsUnknownVersionsAndSubscribers(t *testing.T) {
+	owner := Implementations.OwnerConstruct()
+	parkingLot := owner.CreateParkingLot(1)
+	parkingLot.Subscribe(&eventRecorder{})
+	recorder := &eventRecorder{}
+	parkingLot.Subscribe(recorder, Implementations.CarParked)
+	data, _ := owner.Snapshot(map[string]Implementations.Notifiable{"recorder": recorder})
+
+	_, missingSubscriber := Implementations.RestoreOwner(data, nil)
+	_, wrongVersion := Implementations.RestoreOwner([]byte(`{"version": 99}`), nil)
+	_, malformed := Implementations.RestoreOwner([]byte(`{`), nil)
+
+	assert.ErrorIs(t, missingSubscriber, Exceptions.ErrInvalidSnapshot)
+	assert.ErrorIs(t, wrongVersion, Exceptions.ErrUnsupportedSnapshotVersion)
+	assert.ErrorIs(t, malformed, Exceptions.ErrInvalidSnapshot)
+}
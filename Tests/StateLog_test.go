@@ -0,0 +1,183 @@
+package Tests
+
+import (
+	"ParkingLot_go/Enums"
+	"ParkingLot_go/Exceptions"
+	"ParkingLot_go/Implementations"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func loggedOwner(clock *manualClock) (*Implementations.Owner, *Implementations.StateLog) {
+	log := Implementations.StateLogConstruct()
+	owner := Implementations.OwnerConstruct()
+	owner.Clock = clock
+	owner.EnableStateLog(log)
+	return owner, log
+}
+
+func changeTypes(changes []Implementations.StateChange) []Implementations.ChangeType {
+	types := []Implementations.ChangeType{}
+	for _, change := range changes {
+		types = append(types, change.Type)
+	}
+	return types
+}
+
+func TestStateLogRecordsEveryChangeInOrder(t *testing.T) {
+	owner, log := loggedOwner(newManualClock())
+	parkingLot := owner.CreateParkingLot(1)
+	attendent := Implementations.AttendentConstructDefault()
+	owner.AssignParkingLotToAttendent(attendent, parkingLot)
+	owner.AddSlot(parkingLot, Enums.COMPACT)
+	ticket, _ := attendent.Park(&Implementations.Car{RegistrationNumber: "AP-1", Color: Enums.RED})
+	attendent.Unpark(ticket)
+
+	changes := log.Changes()
+
+	assert.Equal(t, []Implementations.ChangeType{
+		Implementations.ChangeOwnerRegistered,
+		Implementations.ChangeLotCreated,
+		Implementations.ChangeAttendentAssigned,
+		Implementations.ChangeAttendentHired,
+		Implementations.ChangeSlotAdded,
+		Implementations.ChangeCarParked,
+		Implementations.ChangeCarAttended,
+		Implementations.ChangeCarUnparked,
+	}, changeTypes(changes))
+	for i, change := range changes {
+		assert.Equal(t, i+1, change.Sequence)
+	}
+	assert.Equal(t, ticket.GetTicketId(), changes[5].TicketId)
+	assert.Equal(t, 1, changes[5].SlotNumber)
+}
+
+func TestReplayRebuildsLotsAttendentsAndTickets(t *testing.T) {
+	owner, log := loggedOwner(newManualClock())
+	parkingLot := owner.CreateParkingLot(2)
+	parkingLot.Name = "Central Garage"
+	owner.TakeSlotOutOfService(parkingLot, 2)
+	attendent := Implementations.AttendentConstructDefault()
+	owner.AssignParkingLotToAttendent(attendent, parkingLot)
+	ticket, _ := attendent.Park(&Implementations.Car{RegistrationNumber: "AP-1", Color: Enums.RED})
+
+	state, err := log.State()
+
+	assert.NoError(t, err)
+	replayedOwner := state.Owners[owner.Id]
+	replayedLot := state.ParkingLots[parkingLot.ParkingLotId]
+	replayedAttendent := state.Attendents[attendent.Id]
+	assert.Equal(t, []*Implementations.ParkingLot{replayedLot}, replayedOwner.OwnerParkingLots)
+	assert.Equal(t, []*Implementations.Attendent{replayedAttendent}, replayedOwner.Attendents)
+	assert.Equal(t, "Central Garage", replayedLot.Name)
+	assert.Equal(t, 1, replayedLot.Capacity())
+	assert.True(t, replayedLot.IsFull())
+	assert.True(t, replayedAttendent.HoldsTicket(ticket))
+	assert.NoError(t, replayedAttendent.Validate())
+
+	car, err := replayedAttendent.Unpark(ticket)
+	assert.NoError(t, err)
+	assert.Equal(t, "AP-1", car.RegistrationNumber)
+	assert.True(t, attendent.HoldsTicket(ticket))
+}
+
+func TestStateAtShowsTheLotAsItWas(t *testing.T) {
+	clock := newManualClock()
+	owner, log := loggedOwner(clock)
+	parkingLot := owner.CreateParkingLot(2)
+	clock.Advance(time.Hour)
+	ticket, _ := parkingLot.Park(&Implementations.Car{RegistrationNumber: "AP-1", Color: Enums.RED})
+	clock.Advance(time.Hour)
+	parkingLot.Park(&Implementations.Car{RegistrationNumber: "AP-2", Color: Enums.BLUE})
+	clock.Advance(time.Hour)
+	parkingLot.Unpark(ticket)
+	start := newManualClock().Now()
+
+	before, _ := log.StateAt(start.Add(30 * time.Minute))
+	during, _ := log.StateAt(start.Add(2 * time.Hour))
+	now, _ := log.StateAt(clock.Now())
+
+	assert.Equal(t, 0, before.ParkingLots[parkingLot.ParkingLotId].CountParkedCars())
+	assert.Equal(t, 2, during.ParkingLots[parkingLot.ParkingLotId].CountParkedCars())
+	assert.True(t, during.ParkingLots[parkingLot.ParkingLotId].IsFull())
+	parked, _ := now.ParkingLots[parkingLot.ParkingLotId].IsCarWithRegistrationNumberParked("AP-1")
+	assert.False(t, parked)
+	assert.Equal(t, 1, now.ParkingLots[parkingLot.ParkingLotId].CountParkedCars())
+}
+
+func TestReplayFollowsRevocationsAndTransfers(t *testing.T) {
+	seller, log := loggedOwner(newManualClock())
+	buyer := Implementations.OwnerConstruct()
+	buyer.EnableStateLog(log)
+	parkingLot := seller.CreateParkingLot(2)
+	attendent := Implementations.AttendentConstructDefault()
+	seller.AssignParkingLotToAttendent(attendent, parkingLot)
+	ticket, _ := attendent.Park(&Implementations.Car{RegistrationNumber: "AP-1", Color: Enums.RED})
+	seller.FireAttendent(attendent, nil)
+	seller.TransferParkingLot(parkingLot, buyer, Implementations.TransferTerms{Policy: Implementations.RevokeAttendents})
+
+	state, err := log.State()
+
+	assert.NoError(t, err)
+	replayedLot := state.ParkingLots[parkingLot.ParkingLotId]
+	assert.Equal(t, state.Owners[buyer.Id], replayedLot.Owner)
+	assert.Empty(t, state.Owners[seller.Id].OwnerParkingLots)
+	assert.Empty(t, state.Owners[seller.Id].Attendents)
+	assert.Empty(t, state.Attendents[attendent.Id].AssignedParkingLots)
+	assert.True(t, state.Owners[buyer.Id].Attendent.HoldsTicket(ticket))
+	assert.Len(t, replayedLot.OwnershipHistory(), 1)
+}
+
+func TestEnablingTheLogRecordsExistingState(t *testing.T) {
+	owner := Implementations.OwnerConstruct()
+	parkingLot := owner.CreateParkingLot(2)
+	owner.SetSlotType(parkingLot, 2, Enums.LARGE)
+	owner.AssignParkingLotToSelf(parkingLot)
+	ticket, _ := owner.Park(&Implementations.Car{RegistrationNumber: "AP-1", Color: Enums.RED})
+	log := Implementations.StateLogConstruct()
+
+	owner.EnableStateLog(log)
+	state, err := log.State()
+
+	assert.NoError(t, err)
+	replayedOwner := state.Owners[owner.Id]
+	replayedLot := replayedOwner.OwnerParkingLots[0]
+	assert.Equal(t, parkingLot.ParkingLotId, replayedLot.ParkingLotId)
+	assert.True(t, replayedOwner.Attendent.HoldsTicket(ticket))
+	car, err := replayedOwner.Unpark(ticket)
+	assert.NoError(t, err)
+	assert.Equal(t, "AP-1", car.RegistrationNumber)
+}
+
+func TestProjectionsFollowTheLog(t *testing.T) {
+	clock := newManualClock()
+	owner, log := loggedOwner(clock)
+	parkingLot := owner.CreateParkingLot(3)
+	ticket, _ := parkingLot.Park(&Implementations.Car{RegistrationNumber: "AP-1", Color: Enums.RED})
+	history := Implementations.HistoryProjectionConstruct()
+	log.Project(history)
+	occupancy := Implementations.OccupancyProjectionConstruct()
+	log.Project(occupancy)
+
+	clock.Advance(2 * time.Hour)
+	parkingLot.Unpark(ticket)
+	parkingLot.Park(&Implementations.Car{RegistrationNumber: "AP-2", Color: Enums.BLUE})
+	owner.TakeSlotOutOfService(parkingLot, 3)
+
+	assert.Equal(t, Implementations.ProjectedOccupancy{ParkingLotId: parkingLot.ParkingLotId, Capacity: 2, Occupied: 1}, occupancy.Lot(parkingLot.ParkingLotId))
+	visits := history.VisitsOf("AP-1")
+	assert.Len(t, visits, 1)
+	assert.Equal(t, ticket.GetTicketId(), visits[0].TicketId)
+	assert.Equal(t, 2*time.Hour, visits[0].LeftAt.Sub(visits[0].ParkedAt))
+	assert.Len(t, history.VisitsTo(parkingLot.ParkingLotId), 2)
+}
+
+func TestReplayRejectsChangesThatDoNotFit(t *testing.T) {
+	_, err := Implementations.Replay([]Implementations.StateChange{
+		{Type: Implementations.ChangeCarParked, ParkingLotId: 7, SlotNumber: 1, TicketId: "ticket"},
+	})
+
+	assert.ErrorIs(t, err, Exceptions.ErrInvalidStateLog)
+}
@@ -0,0 +1,106 @@
+package Tests
+
+import (
+	"ParkingLot_go/Enums"
+	"ParkingLot_go/Implementations"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUnsubscribeStopsDeliveries(t *testing.T) {
+	owner := Implementations.OwnerConstruct()
+	parkingLot := owner.CreateParkingLot(2)
+	recorder := &eventRecorder{}
+	subscription := parkingLot.Subscribe(recorder, Implementations.CarParked)
+	parkingLot.Park(&Implementations.Car{RegistrationNumber: "AP-1", Color: Enums.RED})
+
+	subscription.Unsubscribe()
+	subscription.Unsubscribe()
+	parkingLot.Park(&Implementations.Car{RegistrationNumber: "AP-2", Color: Enums.RED})
+
+	assert.Len(t, recorder.events, 1)
+	assert.False(t, subscription.IsActive())
+}
+
+func TestRegisteringTheSamePolicemanTwiceNotifiesOnce(t *testing.T) {
+	owner := Implementations.OwnerConstruct()
+	parkingLot := owner.CreateParkingLot(1)
+	recorder := &eventRecorder{}
+
+	first := owner.RegisterNotifiable(parkingLot, recorder)
+	second := owner.RegisterNotifiable(parkingLot, recorder)
+	parkingLot.Subscribe(recorder)
+	parkingLot.Park(&Implementations.Car{RegistrationNumber: "AP-1", Color: Enums.RED})
+
+	assert.Same(t, first, second)
+	assert.Equal(t, []Implementations.EventType{Implementations.CarParked, Implementations.LotFull}, recorder.types())
+}
+
+func TestFilterByLevelAndVehicleAttributes(t *testing.T) {
+	owner := Implementations.OwnerConstruct()
+	parkingLot := owner.CreateParkingLot(3)
+	warnings := &eventRecorder{}
+	redSuvs := &eventRecorder{}
+	parkingLot.SubscribeWithFilter(warnings, Implementations.EventFilter{MinLevel: Implementations.Warning})
+	parkingLot.SubscribeWithFilter(redSuvs, Implementations.EventFilter{
+		Colors:       []Enums.Color{Enums.RED},
+		VehicleTypes: []Enums.VehicleType{Enums.SUV},
+	})
+
+	parkingLot.Park(&Implementations.Car{RegistrationNumber: "AP-1", Color: Enums.RED})
+	parkingLot.Park(&Implementations.Car{RegistrationNumber: "AP-2", Color: Enums.RED, VehicleType: Enums.SUV})
+	parkingLot.Park(&Implementations.Car{RegistrationNumber: "AP-3", Color: Enums.BLUE, VehicleType: Enums.SUV})
+
+	assert.Equal(t, []Implementations.EventType{Implementations.LotFull}, warnings.types())
+	assert.Len(t, redSuvs.events, 1)
+	assert.Equal(t, "AP-2", redSuvs.events[0].Vehicle.RegistrationNumber)
+}
+
+func TestOwnerSubscribesToCurrentAndFutureLots(t *testing.T) {
+	owner := Implementations.OwnerConstruct()
+	existingLot := owner.CreateParkingLot(1)
+	recorder := &eventRecorder{}
+	subscription := owner.SubscribeAll(recorder, Implementations.EventFilter{EventTypes: []Implementations.EventType{Implementations.CarParked}})
+	laterLot := owner.CreateParkingLot(1)
+
+	existingLot.Park(&Implementations.Car{RegistrationNumber: "AP-1", Color: Enums.RED})
+	laterLot.Park(&Implementations.Car{RegistrationNumber: "AP-2", Color: Enums.RED})
+	assert.Len(t, recorder.events, 2)
+
+	subscription.Unsubscribe()
+	owner.CreateParkingLot(1).Park(&Implementations.Car{RegistrationNumber: "AP-3", Color: Enums.RED})
+	assert.Len(t, recorder.events, 2)
+}
+
+func TestOwnerFilterByLotNarrowsSubscribeAll(t *testing.T) {
+	owner := Implementations.OwnerConstruct()
+	watchedLot := owner.CreateParkingLot(1)
+	otherLot := owner.CreateParkingLot(1)
+	recorder := &eventRecorder{}
+	owner.SubscribeAll(recorder, Implementations.EventFilter{ParkingLotIds: []int{watchedLot.GetParkingLotId()}})
+
+	otherLot.Park(&Implementations.Car{RegistrationNumber: "AP-1", Color: Enums.RED})
+	watchedLot.Park(&Implementations.Car{RegistrationNumber: "AP-2", Color: Enums.RED})
+
+	for _, event := range recorder.events {
+		assert.Equal(t, watchedLot.GetParkingLotId(), event.ParkingLotId)
+	}
+	assert.NotEmpty(t, recorder.events)
+}
+
+func TestSoldLotFollowsTheBuyersOwnerWideSubscriptions(t *testing.T) {
+	seller := Implementations.OwnerConstruct()
+	buyer := Implementations.OwnerConstruct()
+	parkingLot := seller.CreateParkingLot(2)
+	sellerFeed := &eventRecorder{}
+	buyerFeed := &eventRecorder{}
+	seller.SubscribeAll(sellerFeed, Implementations.EventFilter{})
+	buyer.SubscribeAll(buyerFeed, Implementations.EventFilter{})
+
+	seller.TransferParkingLot(parkingLot, buyer, Implementations.TransferTerms{Policy: Implementations.RevokeAttendents})
+	parkingLot.Park(&Implementations.Car{RegistrationNumber: "AP-1", Color: Enums.RED})
+
+	assert.Empty(t, sellerFeed.events)
+	assert.Len(t, buyerFeed.events, 1)
+}
@@ -0,0 +1,89 @@
+package Tests
+
+import (
+	"ParkingLot_go/Enums"
+	"ParkingLot_go/Exceptions"
+	"ParkingLot_go/Implementations"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAttendentSatisfiesAttendable(t *testing.T) {
+	owner := Implementations.OwnerConstruct()
+	var attendable Implementations.Attendable = Implementations.AttendentConstructDefault()
+
+	assert.NoError(t, attendable.Assign(owner.CreateParkingLot(1), owner))
+	_, err := attendable.Park(&Implementations.Car{RegistrationNumber: "AP-1234", Color: Enums.RED})
+	assert.NoError(t, err)
+	assert.Len(t, attendable.ParkedCarsView(), 1)
+}
+
+func TestSupervisorSpreadsLotsAcrossTeam(t *testing.T) {
+	owner := Implementations.OwnerConstruct()
+	firstAttendent := Implementations.AttendentConstructDefault()
+	secondAttendent := Implementations.AttendentConstructDefault()
+	supervisor := Implementations.SupervisorConstruct(firstAttendent, secondAttendent)
+
+	assert.NoError(t, supervisor.Assign(owner.CreateParkingLot(1), owner))
+	assert.NoError(t, supervisor.Assign(owner.CreateParkingLot(1), owner))
+
+	assert.Len(t, firstAttendent.AssignedParkingLots, 1)
+	assert.Len(t, secondAttendent.AssignedParkingLots, 1)
+	assert.Len(t, supervisor.AssignedLots(), 2)
+}
+
+func TestSupervisorRejectsLotAlreadyAssignedToTeam(t *testing.T) {
+	owner := Implementations.OwnerConstruct()
+	parkingLot := owner.CreateParkingLot(1)
+	supervisor := Implementations.SupervisorConstruct(Implementations.AttendentConstructDefault(), Implementations.AttendentConstructDefault())
+
+	supervisor.Assign(parkingLot, owner)
+	assert.ErrorIs(t, supervisor.Assign(parkingLot, owner), Exceptions.ErrParkingLotAlreadyAssigned)
+}
+
+func TestSupervisorRoutesParkAndUnparkThroughTeam(t *testing.T) {
+	owner := Implementations.OwnerConstruct()
+	firstAttendent := Implementations.AttendentConstructDefault()
+	secondAttendent := Implementations.AttendentConstructDefault()
+	owner.AssignParkingLotToAttendent(firstAttendent, owner.CreateParkingLot(1))
+	owner.AssignParkingLotToAttendent(secondAttendent, owner.CreateParkingLot(1))
+	supervisor := Implementations.SupervisorConstruct(firstAttendent, secondAttendent)
+
+	firstTicket, err := supervisor.Park(&Implementations.Car{RegistrationNumber: "AP-1234", Color: Enums.RED})
+	assert.NoError(t, err)
+	_, err = supervisor.Park(&Implementations.Car{RegistrationNumber: "AP-5678", Color: Enums.BLUE})
+	assert.NoError(t, err)
+	_, err = supervisor.Park(&Implementations.Car{RegistrationNumber: "AP-9012", Color: Enums.GREEN})
+	assert.ErrorIs(t, err, Exceptions.ErrNoAttendentAvailable)
+	assert.Len(t, supervisor.ParkedCarsView(), 2)
+
+	car, err := supervisor.Unpark(firstTicket)
+	assert.NoError(t, err)
+	assert.Equal(t, "AP-1234", car.RegistrationNumber)
+	_, err = supervisor.Unpark(firstTicket)
+	assert.ErrorIs(t, err, Exceptions.ErrCarNotFound)
+}
+
+func TestNestedSupervisorsAggregateParkedCars(t *testing.T) {
+	owner := Implementations.OwnerConstruct()
+	attendent := Implementations.AttendentConstructDefault()
+	owner.AssignParkingLotToAttendent(attendent, owner.CreateParkingLot(3))
+	teamLead := Implementations.SupervisorConstruct(attendent)
+	manager := Implementations.SupervisorConstruct(teamLead)
+
+	ticket, err := manager.Park(&Implementations.Car{RegistrationNumber: "AP-1234", Color: Enums.RED})
+	assert.NoError(t, err)
+	assert.True(t, manager.HoldsTicket(ticket))
+	assert.Len(t, manager.ParkedCarsView(), 1)
+	assert.Len(t, teamLead.ParkedCarsView(), 1)
+}
+
+func TestSupervisorCannotSuperviseItself(t *testing.T) {
+	teamLead := Implementations.SupervisorConstruct()
+	manager := Implementations.SupervisorConstruct(teamLead)
+
+	assert.ErrorIs(t, manager.AddToTeam(manager), Exceptions.ErrSupervisorCycle)
+	assert.ErrorIs(t, teamLead.AddToTeam(manager), Exceptions.ErrSupervisorCycle)
+	assert.ErrorIs(t, manager.AddToTeam(teamLead), Exceptions.ErrSupervisorCycle)
+}
@@ -0,0 +1,99 @@
+package Tests
+
+import (
+	"ParkingLot_go/Enums"
+	"ParkingLot_go/Exceptions"
+	"ParkingLot_go/Implementations"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFailedLotParkLeavesAttendentBookkeepingUntouched(t *testing.T) {
+	owner := Implementations.OwnerConstruct()
+	parkingLot := owner.CreateParkingLot(2)
+	attendent := Implementations.AttendentConstructDefault()
+	owner.AssignParkingLotToAttendent(attendent, parkingLot)
+	parkingLot.Park(&Implementations.Car{RegistrationNumber: "AP-1234", Color: Enums.RED})
+
+	_, err := attendent.Park(&Implementations.Car{RegistrationNumber: "AP-1234", Color: Enums.RED})
+
+	assert.ErrorIs(t, err, Exceptions.ErrCarAlreadyParked)
+	assert.Empty(t, attendent.ParkedCars)
+	assert.Equal(t, 1, parkingLot.CountParkedCars())
+	assert.NoError(t, attendent.Validate())
+}
+
+func TestUnparkRemovesCarFromAttendentBookkeeping(t *testing.T) {
+	owner := Implementations.OwnerConstruct()
+	parkingLot := owner.CreateParkingLot(2)
+	attendent := Implementations.AttendentConstructDefault()
+	owner.AssignParkingLotToAttendent(attendent, parkingLot)
+	car := &Implementations.Car{RegistrationNumber: "AP-1234", Color: Enums.RED}
+
+	ticket, _ := attendent.Park(car)
+	assert.Len(t, attendent.ParkedCars, 1)
+	_, err := attendent.Unpark(ticket)
+
+	assert.NoError(t, err)
+	assert.Empty(t, attendent.ParkedCars)
+	assert.NoError(t, attendent.Validate())
+	_, err = attendent.Park(car)
+	assert.NoError(t, err)
+}
+
+func TestUnparkRoutesTicketToIssuingLot(t *testing.T) {
+	owner := Implementations.OwnerConstruct()
+	firstLot := owner.CreateParkingLot(1)
+	secondLot := owner.CreateParkingLot(1)
+	attendent := Implementations.AttendentConstructDefault()
+	owner.AssignParkingLotToAttendent(attendent, firstLot)
+	owner.AssignParkingLotToAttendent(attendent, secondLot)
+
+	attendent.Park(&Implementations.Car{RegistrationNumber: "AP-1234", Color: Enums.RED})
+	ticket, _ := attendent.Park(&Implementations.Car{RegistrationNumber: "AP-5678", Color: Enums.BLUE})
+	car, err := attendent.Unpark(ticket)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "AP-5678", car.RegistrationNumber)
+	assert.Equal(t, 1, firstLot.CountParkedCars())
+	assert.Equal(t, 0, secondLot.CountParkedCars())
+}
+
+func TestAttendentUnparksCarParkedDirectlyInAssignedLot(t *testing.T) {
+	owner := Implementations.OwnerConstruct()
+	parkingLot := owner.CreateParkingLot(2)
+	attendent := Implementations.AttendentConstructDefault()
+	owner.AssignParkingLotToAttendent(attendent, parkingLot)
+
+	ticket, _ := parkingLot.Park(&Implementations.Car{RegistrationNumber: "AP-1234", Color: Enums.RED})
+	car, err := attendent.Unpark(ticket)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "AP-1234", car.RegistrationNumber)
+	assert.NoError(t, attendent.Validate())
+}
+
+func TestValidateDetectsCarRemovedBehindAttendentsBack(t *testing.T) {
+	owner := Implementations.OwnerConstruct()
+	parkingLot := owner.CreateParkingLot(2)
+	attendent := Implementations.AttendentConstructDefault()
+	owner.AssignParkingLotToAttendent(attendent, parkingLot)
+
+	ticket, _ := attendent.Park(&Implementations.Car{RegistrationNumber: "AP-1234", Color: Enums.RED})
+	parkingLot.Unpark(ticket)
+
+	err := attendent.Validate()
+	assert.ErrorIs(t, err, Exceptions.ErrInconsistentState)
+}
+
+func TestParkingLotRejectsUnknownTicketWithoutChangingSlots(t *testing.T) {
+	owner := Implementations.OwnerConstruct()
+	parkingLot := owner.CreateParkingLot(1)
+	parkingLot.Park(&Implementations.Car{RegistrationNumber: "AP-1234", Color: Enums.RED})
+
+	_, err := parkingLot.Unpark(&Implementations.Ticket{})
+
+	assert.ErrorIs(t, err, Exceptions.ErrInvalidTicket)
+	assert.True(t, parkingLot.IsFull())
+}
@@ -0,0 +1,91 @@
+package Tests
+
+import (
+	"ParkingLot_go/Enums"
+	"ParkingLot_go/Implementations"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPolicemanAlertedWhenWatchedCarParks(t *testing.T) {
+	clock := newManualClock()
+	owner := Implementations.OwnerConstruct()
+	owner.Clock = clock
+	parkingLot := owner.CreateParkingLot(3)
+	policeman := Implementations.PolicemanConstruct()
+	alerts := &eventRecorder{}
+	policeman.Alerts = alerts
+	policeman.Watch("ka 01-ab-1234", "stolen")
+	policeman.Patrol(parkingLot)
+
+	parkingLot.Park(&Implementations.Car{RegistrationNumber: "AP-1", Color: Enums.RED})
+	ticket, _ := parkingLot.Park(&Implementations.Car{RegistrationNumber: "KA01AB1234", Color: Enums.BLACK})
+
+	assert.Len(t, alerts.events, 1)
+	assert.Equal(t, Implementations.WatchlistMatched, alerts.events[0].Type)
+	assert.Equal(t, Implementations.Critical, alerts.events[0].Level())
+	matches := policeman.Matches()
+	assert.Len(t, matches, 1)
+	assert.Equal(t, "KA01AB1234", matches[0].RegistrationNumber)
+	assert.Equal(t, "stolen", matches[0].Reason)
+	assert.Equal(t, parkingLot.GetParkingLotId(), matches[0].ParkingLotId)
+	assert.Equal(t, 2, matches[0].Slot.SlotNumber)
+	assert.Equal(t, ticket.GetTicketId(), matches[0].TicketId)
+	assert.Equal(t, clock.Now(), matches[0].At)
+}
+
+func TestUnwatchedPlateNoLongerAlerts(t *testing.T) {
+	owner := Implementations.OwnerConstruct()
+	parkingLot := owner.CreateParkingLot(2)
+	policeman := Implementations.PolicemanConstruct()
+	policeman.Watch("AP-1", "warrant")
+	policeman.Watch("AP-2", "warrant")
+	policeman.Unwatch("ap-1")
+	policeman.Patrol(parkingLot)
+
+	parkingLot.Park(&Implementations.Car{RegistrationNumber: "AP-1", Color: Enums.RED})
+
+	assert.Empty(t, policeman.Matches())
+	assert.False(t, policeman.IsWatching("AP-1"))
+	assert.Equal(t, []string{"AP2"}, policeman.Watchlist())
+}
+
+func TestWatchlistCoversEveryLotPatrolled(t *testing.T) {
+	owner := Implementations.OwnerConstruct()
+	firstLot := owner.CreateParkingLot(1)
+	secondLot := owner.CreateParkingLot(1)
+	unpatrolledLot := owner.CreateParkingLot(1)
+	policeman := Implementations.PolicemanConstruct()
+	policeman.Watch("AP-9", "stolen")
+	policeman.Patrol(firstLot)
+	policeman.Patrol(secondLot)
+
+	ticket, _ := firstLot.Park(&Implementations.Car{RegistrationNumber: "AP-9", Color: Enums.RED})
+	firstLot.Unpark(ticket)
+	secondLot.Park(&Implementations.Car{RegistrationNumber: "AP-9", Color: Enums.RED})
+	unpatrolledLot.Park(&Implementations.Car{RegistrationNumber: "AP-9", Color: Enums.RED})
+
+	matches := policeman.Matches()
+	assert.Len(t, matches, 2)
+	assert.Equal(t, firstLot.GetParkingLotId(), matches[0].ParkingLotId)
+	assert.Equal(t, secondLot.GetParkingLotId(), matches[1].ParkingLotId)
+}
+
+func TestRegisteredPolicemanChecksArrivalsToo(t *testing.T) {
+	owner := Implementations.OwnerConstruct()
+	parkingLot := owner.CreateParkingLot(2)
+	otherLot := owner.CreateParkingLot(2)
+	policeman := Implementations.PolicemanConstruct()
+	policeman.Watch("AP-9", "stolen")
+	parkingLot.RegisterNotifiable(policeman)
+	owner.RegisterNotifiable(otherLot, policeman)
+
+	parkingLot.Park(&Implementations.Car{RegistrationNumber: "AP-9", Color: Enums.RED})
+	otherLot.Park(&Implementations.Car{RegistrationNumber: "AP-9", Color: Enums.RED})
+
+	matches := policeman.Matches()
+	assert.Len(t, matches, 2)
+	assert.Equal(t, parkingLot.GetParkingLotId(), matches[0].ParkingLotId)
+	assert.Equal(t, otherLot.GetParkingLotId(), matches[1].ParkingLotId)
+}
@@ -0,0 +1,228 @@
+package Tests
+
+import (
+	"ParkingLot_go/Enums"
+	"ParkingLot_go/Exceptions"
+	"ParkingLot_go/Implementations"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type webhookRequest struct {
+	header http.Header
+	body   []byte
+}
+
+type webhookReceiver struct {
+	mutex    sync.Mutex
+	requests []webhookRequest
+	statuses []int
+}
+
+func (receiver *webhookReceiver) ServeHTTP(writer http.ResponseWriter, request *http.Request) {
+	body, _ := io.ReadAll(request.Body)
+	receiver.mutex.Lock()
+	defer receiver.mutex.Unlock()
+	receiver.requests = append(receiver.requests, webhookRequest{header: request.Header.Clone(), body: body})
+	status := http.StatusOK
+	if len(receiver.statuses) > 0 {
+		status = receiver.statuses[0]
+		receiver.statuses = receiver.statuses[1:]
+	}
+	writer.WriteHeader(status)
+}
+
+// webhookBus puts the webhook on a bus that retries quickly.
+func webhookBus(webhook *Implementations.Webhook, maxAttempts int) *Implementations.EventBus {
+	bus := Implementations.EventBusConstruct()
+	bus.Subscribe(webhook, Implementations.SubscriberOptions{Name: "webhook", MaxAttempts: maxAttempts, InitialBackoff: time.Millisecond})
+	return bus
+}
+
+func TestWebhookPostsSignedEventWhenLotFills(t *testing.T) {
+	receiver := &webhookReceiver{}
+	server := httptest.NewServer(receiver)
+	defer server.Close()
+	owner := Implementations.OwnerConstruct()
+	parkingLot := owner.CreateParkingLot(1)
+	bus := webhookBus(Implementations.WebhookConstruct("s3cret", server.URL), 1)
+	parkingLot.Subscribe(bus, Implementations.LotFull)
+
+	parkingLot.Park(&Implementations.Car{RegistrationNumber: "AP-1", Color: Enums.RED})
+	shutdown(t, bus)
+
+	assert.Len(t, receiver.requests, 1)
+	request := receiver.requests[0]
+	timestamp := request.header.Get(Implementations.WebhookTimestampHeader)
+	_, err := strconv.ParseInt(timestamp, 10, 64)
+	assert.NoError(t, err)
+	assert.NotEmpty(t, request.header.Get(Implementations.WebhookDeliveryHeader))
+	assert.True(t, Implementations.VerifyWebhook("s3cret", timestamp, request.body, request.header.Get(Implementations.WebhookSignatureHeader)))
+	assert.False(t, Implementations.VerifyWebhook("wrong", timestamp, request.body, request.header.Get(Implementations.WebhookSignatureHeader)))
+
+	var payload struct {
+		DeliveryId string                `json:"delivery_id"`
+		Event      Implementations.Event `json:"event"`
+	}
+	assert.NoError(t, json.Unmarshal(request.body, &payload))
+	assert.Equal(t, request.header.Get(Implementations.WebhookDeliveryHeader), payload.DeliveryId)
+	assert.Equal(t, Implementations.LotFull, payload.Event.Type)
+	assert.Equal(t, parkingLot.GetParkingLotId(), payload.Event.ParkingLotId)
+}
+
+func TestWebhookRetriesServerErrorsWithTheSameDeliveryId(t *testing.T) {
+	receiver := &webhookReceiver{statuses: []int{http.StatusServiceUnavailable, http.StatusInternalServerError}}
+	server := httptest.NewServer(receiver)
+	defer server.Close()
+	webhook := Implementations.WebhookConstruct("s3cret", server.URL)
+	bus := webhookBus(webhook, 3)
+
+	bus.Publish(Implementations.Event{Type: Implementations.LotAvailable, ParkingLotId: 9})
+	shutdown(t, bus)
+
+	assert.Empty(t, bus.DeadLetters())
+	assert.Len(t, receiver.requests, 3)
+	deliveryId := receiver.requests[0].header.Get(Implementations.WebhookDeliveryHeader)
+	assert.Equal(t, deliveryId, receiver.requests[2].header.Get(Implementations.WebhookDeliveryHeader))
+	deliveries := webhook.Deliveries()
+	assert.Equal(t, Implementations.Delivered, deliveries[0].Status)
+	assert.Equal(t, 3, deliveries[0].Attempts)
+	assert.Equal(t, http.StatusOK, deliveries[0].StatusCode)
+}
+
+func TestWebhookRecordsFailureAfterLastAttempt(t *testing.T) {
+	receiver := &webhookReceiver{statuses: []int{500, 500, 500}}
+	server := httptest.NewServer(receiver)
+	defer server.Close()
+	webhook := Implementations.WebhookConstruct("s3cret", server.URL)
+	bus := webhookBus(webhook, 3)
+
+	bus.Publish(Implementations.Event{Type: Implementations.LotFull, ParkingLotId: 9})
+	shutdown(t, bus)
+
+	assert.ErrorIs(t, bus.DeadLetters()[0].Err, Exceptions.ErrWebhookDeliveryFailed)
+	delivery := webhook.Deliveries()[0]
+	assert.Equal(t, Implementations.DeliveryFailed, delivery.Status)
+	assert.Equal(t, 3, delivery.Attempts)
+	assert.Equal(t, http.StatusInternalServerError, delivery.StatusCode)
+}
+
+func TestWebhookDoesNotRetryClientErrors(t *testing.T) {
+	receiver := &webhookReceiver{statuses: []int{http.StatusBadRequest}}
+	server := httptest.NewServer(receiver)
+	defer server.Close()
+	webhook := Implementations.WebhookConstruct("s3cret", server.URL)
+	bus := webhookBus(webhook, 3)
+
+	bus.Publish(Implementations.Event{Type: Implementations.LotFull, ParkingLotId: 9})
+	shutdown(t, bus)
+
+	assert.Len(t, bus.DeadLetters(), 1)
+	assert.Equal(t, 1, bus.DeadLetters()[0].Attempts)
+	assert.Len(t, receiver.requests, 1)
+}
+
+func TestWebhookStartsAfreshOnceTheBusGivesUp(t *testing.T) {
+	receiver := &webhookReceiver{statuses: []int{500}}
+	server := httptest.NewServer(receiver)
+	defer server.Close()
+	webhook := Implementations.WebhookConstruct("s3cret", server.URL)
+	bus := webhookBus(webhook, 1)
+	event := Implementations.Event{Type: Implementations.LotFull, ParkingLotId: 9}
+
+	bus.Publish(event)
+	bus.Publish(event)
+	shutdown(t, bus)
+
+	deliveries := webhook.Deliveries()
+	assert.Len(t, deliveries, 2)
+	assert.Equal(t, Implementations.DeliveryFailed, deliveries[0].Status)
+	assert.Equal(t, Implementations.Delivered, deliveries[1].Status)
+	assert.NotEqual(t, deliveries[0].DeliveryId, deliveries[1].DeliveryId)
+}
+
+func TestWebhookRetryDeliversToUrlsAddedSinceTheLastAttempt(t *testing.T) {
+	flaky := &webhookReceiver{statuses: []int{http.StatusServiceUnavailable}}
+	flakyServer := httptest.NewServer(flaky)
+	defer flakyServer.Close()
+	added := &webhookReceiver{}
+	addedServer := httptest.NewServer(added)
+	defer addedServer.Close()
+	webhook := Implementations.WebhookConstruct("s3cret", flakyServer.URL)
+	event := Implementations.Event{Type: Implementations.LotFull, ParkingLotId: 9}
+
+	assert.ErrorIs(t, webhook.Handle(event), Exceptions.ErrWebhookDeliveryFailed)
+	webhook.URLs = append(webhook.URLs, addedServer.URL)
+	assert.NoError(t, webhook.Handle(event))
+
+	assert.Len(t, flaky.requests, 2)
+	assert.Len(t, added.requests, 1)
+	assert.Len(t, webhook.Deliveries(), 2)
+}
+
+func TestWebhookRetryOnlyRepostsToFailedUrls(t *testing.T) {
+	healthy := &webhookReceiver{}
+	healthyServer := httptest.NewServer(healthy)
+	defer healthyServer.Close()
+	flaky := &webhookReceiver{statuses: []int{http.StatusServiceUnavailable}}
+	flakyServer := httptest.NewServer(flaky)
+	defer flakyServer.Close()
+	webhook := Implementations.WebhookConstruct("s3cret", healthyServer.URL, flakyServer.URL)
+	event := Implementations.Event{Type: Implementations.LotFull, ParkingLotId: 9}
+
+	assert.ErrorIs(t, webhook.Handle(event), Exceptions.ErrWebhookDeliveryFailed)
+	assert.NoError(t, webhook.Handle(event))
+
+	assert.Len(t, healthy.requests, 1)
+	assert.Len(t, flaky.requests, 2)
+	assert.Len(t, webhook.Deliveries(), 2)
+}
+
+func TestParkingDoesNotWaitForASlowWebhook(t *testing.T) {
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		<-release
+	}))
+	defer server.Close()
+	owner := Implementations.OwnerConstruct()
+	parkingLot := owner.CreateParkingLot(1)
+	bus := webhookBus(Implementations.WebhookConstruct("s3cret", server.URL), 1)
+	parkingLot.Subscribe(bus, Implementations.LotFull)
+
+	parked := make(chan struct{})
+	go func() {
+		parkingLot.Park(&Implementations.Car{RegistrationNumber: "AP-1", Color: Enums.RED})
+		close(parked)
+	}()
+	select {
+	case <-parked:
+	case <-time.After(time.Second):
+		t.Fatal("parking waited for the webhook")
+	}
+	close(release)
+	shutdown(t, bus)
+}
+
+func TestWebhookFailuresReachTheBusDeadLetterQueue(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		writer.WriteHeader(http.StatusBadGateway)
+	}))
+	defer server.Close()
+	webhook := Implementations.WebhookConstruct("s3cret", server.URL)
+	bus := Implementations.EventBusConstruct()
+	bus.Subscribe(webhook, Implementations.SubscriberOptions{Name: "ops-webhook"})
+
+	bus.Publish(Implementations.Event{Type: Implementations.LotFull, ParkingLotId: 3})
+	shutdown(t, bus)
+
+	assert.Len(t, bus.DeadLetters(), 1)
+	assert.ErrorIs(t, bus.DeadLetters()[0].Err, Exceptions.ErrWebhookDeliveryFailed)
+}